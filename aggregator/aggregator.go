@@ -2,66 +2,219 @@
 package aggregator
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/jsonfeed"
 	"github.com/grokify/signal/opml"
 	"github.com/mmcdole/gofeed"
 )
 
+// DefaultUserAgent is the User-Agent sent when none is configured.
+const DefaultUserAgent = "Signal/1.0 (+https://github.com/grokify/signal)"
+
+// DefaultAccept is the Accept header sent with feed requests unless an
+// outline overrides it. Feed types come first so servers doing strict
+// content negotiation serve the feed rather than a 406 or an HTML page.
+const DefaultAccept = "application/rss+xml, application/atom+xml, application/feed+json, application/json;q=0.9, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+
 // Config holds aggregator configuration.
 type Config struct {
-	// UserAgent for HTTP requests
+	// UserAgent for HTTP requests (outlines may override per feed)
 	UserAgent string
-	// Timeout for each feed fetch
+	// Timeout for each feed fetch, including reading the body
 	Timeout time.Duration
+	// ConnectTimeout bounds establishing a connection, so unreachable
+	// hosts fail fast (0 = Timeout)
+	ConnectTimeout time.Duration
+	// ResponseHeaderTimeout bounds waiting for response headers after the
+	// request is sent (0 = Timeout)
+	ResponseHeaderTimeout time.Duration
 	// MaxEntries limits the number of entries per feed (0 = unlimited)
 	MaxEntries int
 	// MaxAge filters out entries older than this duration (0 = no limit)
 	MaxAge time.Duration
+	// MinDate drops entries dated before it as bogus, e.g., the 1970 or
+	// year 0 dates of feeds with broken timestamps (zero = no floor)
+	MinDate time.Time
+	// FutureDates selects whether entries dated more than FutureSkew ahead
+	// of the fetch are kept (the default), dropped, or clamped to the
+	// fetch time
+	FutureDates FutureDatePolicy
+	// FutureSkew is how far ahead an entry may be dated before FutureDates
+	// applies (0 = not at all)
+	FutureSkew time.Duration
+	// PreferOPMLTitle names every source by its outline title, when set,
+	// rather than the title the feed reports, as Outline.TitleOverride
+	// does per feed. This keeps by-source slugs stable when a feed renames
+	// itself or reports an unhelpful title.
+	PreferOPMLTitle bool
+	// OPMLCategoriesAsTags adds each outline's categories to the tags of
+	// its entries (and records them as OutlineTags). When false, the
+	// categories stay on the outline, e.g., for the API's sources.json.
+	OPMLCategoriesAsTags bool
+	// AuthorFromFeedTitle credits entries whose feed item names no author,
+	// and whose outline sets no DefaultAuthor, to the source feed's title,
+	// as suits single-author blogs
+	AuthorFromFeedTitle bool
 	// FilterTags only includes entries matching these tags (empty = all)
 	FilterTags []string
-	// Concurrency controls parallel feed fetching
+	// Concurrency controls parallel feed fetching: 1 fetches feeds one at a
+	// time in order, for deterministic debugging, and 0 or less is unbounded
 	Concurrency int
+	// DedupByTitle additionally collapses entries sharing a normalized
+	// title and author within entry.DefaultTitleDedupWindow. Opt-in since
+	// series posts with identical titles can be false positives.
+	DedupByTitle bool
+	// DedupScope selects whether URL deduplication collapses entries
+	// across sources (entry.DedupGlobal, the default) or only within one
+	// source (entry.DedupPerSource), linking the copies via AlsoIn.
+	DedupScope entry.DedupScope
+	// DedupBySummary additionally collapses entries from one source with
+	// identical normalized summaries (teaser and full items for one post)
+	DedupBySummary bool
+	// EntryHook, if set, is called on each entry built by FetchFeed before
+	// it is added to the result, allowing entries to be mutated or annotated.
+	// It runs concurrently across feeds, so implementations must be thread-safe.
+	EntryHook func(*entry.Entry)
+	// RespectRobots makes FetchPage honor robots.txt for article fetches
+	RespectRobots bool
+	// CrawlDelay is the minimum delay between FetchPage requests to one host
+	CrawlDelay time.Duration
+	// AbsolutizeLinks rewrites relative href/src URLs in summaries and
+	// content against the item link (or the feed's home URL)
+	AbsolutizeLinks bool
+	// IncludeRaw attaches a snapshot of original feed item fields to each
+	// entry's Raw field, for debugging feed quirks
+	IncludeRaw bool
+	// FetchMetaSummary fetches the article page of entries whose feed item
+	// has neither a description nor content, and uses its meta description
+	// as the summary. Pages go through FetchPage.
+	FetchMetaSummary bool
+	// PreferGUID derives entry IDs from item GUIDs (RSS <guid>, Atom <id>)
+	// when present, so IDs survive feeds rewriting URLs. Off by default
+	// since it changes the IDs of already stored entries.
+	PreferGUID bool
+	// CleanContent strips HTML comments, tracking pixels, and empty
+	// elements from summaries and content (see entry.CleanContent)
+	CleanContent bool
+	// MaxContentBytes caps each entry's content at this many characters
+	// (runes, counting markup), cutting it HTML-aware and flagging it as
+	// truncated (0 = unlimited). Summaries are not cut.
+	MaxContentBytes int
+	// PreferCanonicalURL replaces an entry's URL with the canonical URL
+	// declared by a <link rel="canonical"> or og:url in its content or, if
+	// absent there, its article page (fetched through FetchPage), keeping
+	// the feed link as ExternalURL. This collapses duplicates where one
+	// source links an AMP or tracking URL and another the canonical one.
+	PreferCanonicalURL bool
+	// FetchImageDimensions reads the width and height of each entry's
+	// image from the start of the image file, for frontends to avoid
+	// layout shift. Failures leave the dimensions unset.
+	FetchImageDimensions bool
+	// FetchFavicons derives a source icon from the site's home page or
+	// /favicon.ico when the feed doesn't advertise an image
+	FetchFavicons bool
+	// GeneratedAt overrides the feed generation time for reproducible output
+	GeneratedAt time.Time
+	// Checkpoint, if set, saves successful results as FetchResults gets
+	// them and supplies saved results instead of refetching, so an
+	// interrupted run can resume
+	Checkpoint *Checkpoint
 }
 
 // DefaultConfig returns a sensible default configuration.
 func DefaultConfig() Config {
 	return Config{
-		UserAgent:   "Signal/1.0 (+https://github.com/grokify/signal)",
-		Timeout:     30 * time.Second,
-		MaxEntries:  50,
-		MaxAge:      0,
-		FilterTags:  nil,
-		Concurrency: 10,
+		UserAgent:            DefaultUserAgent,
+		Timeout:              30 * time.Second,
+		MaxEntries:           50,
+		MaxAge:               0,
+		FilterTags:           nil,
+		Concurrency:          10,
+		FutureSkew:           DefaultFutureSkew,
+		RespectRobots:        true,
+		OPMLCategoriesAsTags: true,
+		CrawlDelay:           time.Second,
 	}
 }
 
 // Aggregator fetches and combines feeds.
 type Aggregator struct {
 	config Config
-	parser *gofeed.Parser
+	client *http.Client
+
+	hostsMu sync.Mutex
+	hosts   map[string]*hostState
+
+	faviconsMu sync.Mutex
+	favicons   map[string]*faviconEntry
 }
 
 // New creates a new Aggregator with the given configuration.
 func New(cfg Config) *Aggregator {
-	parser := gofeed.NewParser()
-	parser.UserAgent = cfg.UserAgent
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
 	return &Aggregator{
-		config: cfg,
-		parser: parser,
+		config:   cfg,
+		client:   &http.Client{Transport: newTransport(cfg)},
+		hosts:    make(map[string]*hostState),
+		favicons: make(map[string]*faviconEntry),
+	}
+}
+
+// newTransport returns an HTTP transport applying the configured connect
+// and response header timeouts, each defaulting to the overall Timeout.
+func newTransport(cfg Config) *http.Transport {
+	connectTimeout := cfg.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = cfg.Timeout
+	}
+	headerTimeout := cfg.ResponseHeaderTimeout
+	if headerTimeout <= 0 {
+		headerTimeout = cfg.Timeout
 	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	t.ResponseHeaderTimeout = headerTimeout
+	return t
 }
 
 // FetchResult holds the result of fetching a single feed.
 type FetchResult struct {
-	Outline opml.Outline
-	Entries []entry.Entry
-	Error   error
+	Outline  opml.Outline
+	Entries  []entry.Entry
+	Error    error
+	Duration time.Duration  // Time spent fetching and parsing the feed
+	Format   string         // Detected feed format, e.g., "RSS 2.0", "Atom 1.0", "JSON Feed 1.1"
+	Feed     entry.FeedMeta // Metadata resolved from the fetched feed, falling back to the outline
+	// BogusDates counts entries dropped for being dated before Config.MinDate
+	BogusDates int
+	// FutureDates counts entries dated ahead of the fetch that were
+	// dropped or clamped per Config.FutureDates
+	FutureDates int
+	// Resumed is set when the result was loaded from Config.Checkpoint
+	// instead of fetched.
+	Resumed bool
 }
 
 // FetchFeed fetches and parses a single feed.
@@ -73,33 +226,58 @@ func (a *Aggregator) FetchFeed(ctx context.Context, outline opml.Outline) FetchR
 		return result
 	}
 
+	pageCtx := ctx // article pages get their own timeouts
 	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
 	defer cancel()
 
-	feed, err := a.parser.ParseURLWithContext(outline.XMLURL, ctx)
+	start := time.Now()
+	feed, jsonItems, err := a.fetch(ctx, outline)
+	result.Duration = time.Since(start)
 	if err != nil {
-		result.Error = fmt.Errorf("failed to parse %s: %w", outline.XMLURL, err)
+		var fetchErr *FetchError
+		if errors.As(err, &fetchErr) {
+			result.Error = err
+		} else {
+			result.Error = fmt.Errorf("failed to parse %s: %w", outline.XMLURL, err)
+		}
 		return result
 	}
 
+	result.Format = feedFormat(feed)
+
 	feedMeta := entry.FeedMeta{
-		Title: feed.Title,
-		URL:   feed.Link,
+		Title:       feed.Title,
+		URL:         feed.Link,
+		Description: strings.TrimSpace(feed.Description),
 	}
-	if feedMeta.Title == "" {
+	if feedMeta.Title == "" || (outline.Title != "" && (outline.TitleOverride || a.config.PreferOPMLTitle)) {
 		feedMeta.Title = outline.Title
 	}
 	if feedMeta.URL == "" {
 		feedMeta.URL = outline.HTMLURL
 	}
+	if feedMeta.Description == "" {
+		feedMeta.Description = outline.Description
+	}
 	if feed.Image != nil {
 		feedMeta.IconURL = feed.Image.URL
 	}
+	if feedMeta.IconURL == "" && a.config.FetchFavicons {
+		feedMeta.IconURL = a.favicon(pageCtx, feedMeta.URL)
+	}
+	result.Feed = feedMeta
+
+	language := outline.Language // the curator's setting wins over the feed's
+	if language == "" {
+		language = strings.TrimSpace(feed.Language)
+	}
 
 	cutoff := time.Time{}
 	if a.config.MaxAge > 0 {
 		cutoff = time.Now().Add(-a.config.MaxAge)
 	}
+	fetchedAt := time.Now()
+	horizon := fetchedAt.Add(a.config.FutureSkew)
 
 	for i, item := range feed.Items {
 		if a.config.MaxEntries > 0 && i >= a.config.MaxEntries {
@@ -113,36 +291,110 @@ func (a *Aggregator) FetchFeed(ctx context.Context, outline opml.Outline) FetchR
 			pubDate = *item.UpdatedParsed
 		}
 
+		if !a.config.MinDate.IsZero() && pubDate.Before(a.config.MinDate) {
+			result.BogusDates++
+			continue
+		}
 		if !cutoff.IsZero() && pubDate.Before(cutoff) {
 			continue
 		}
+		idDate, clamped := pubDate, false
+		if a.config.FutureDates != FutureKeep && pubDate.After(horizon) {
+			result.FutureDates++
+			if a.config.FutureDates == FutureDrop {
+				continue
+			}
+			pubDate, clamped = fetchedAt, true
+		}
 
 		// Combine feed categories with outline categories
-		tags := append([]string{}, outline.Categories...)
+		var outlineTags []string
+		if a.config.OPMLCategoriesAsTags {
+			outlineTags = uniqueStrings(outline.Categories)
+		}
+		tags := append([]string{}, outlineTags...)
 		tags = append(tags, item.Categories...)
 
 		author := ""
 		if item.Author != nil {
 			author = item.Author.Name
 		}
+		if author == "" {
+			author = outline.DefaultAuthor
+		}
+		if author == "" && a.config.AuthorFromFeedTitle {
+			author = feedMeta.Title
+		}
 
 		summary := item.Description
 		content := item.Content
 		if summary == "" && content != "" {
-			// Use first 500 chars of content as summary
-			summary = truncateHTML(content, 500)
+			// Use the first 500 characters of content as summary
+			summary, _ = entry.TruncateHTMLWithMarker(content, 500, "...")
+		}
+		if summary == "" && content == "" && a.config.FetchMetaSummary && item.Link != "" {
+			summary = a.metaSummary(pageCtx, item.Link)
+		}
+		if a.config.AbsolutizeLinks {
+			base := item.Link
+			if u, err := url.Parse(base); err != nil || !u.IsAbs() {
+				base = feedMeta.URL
+			}
+			summary = entry.AbsolutizeLinks(summary, base)
+			content = entry.AbsolutizeLinks(content, base)
+		}
+		if a.config.CleanContent {
+			summary = entry.CleanContent(summary)
+			content = entry.CleanContent(content)
+		}
+		// Count words before the size cap so WordCount reflects the full post.
+		wordCount := entry.WordCount(content)
+		if content == "" {
+			wordCount = entry.WordCount(summary)
+		}
+		content, truncated := entry.TruncateHTML(content, a.config.MaxContentBytes)
+
+		link, externalURL := item.Link, ""
+		if a.config.PreferCanonicalURL && item.Link != "" {
+			if c := a.canonicalURL(pageCtx, item.Link, item.Content); c != "" && !sameURL(c, item.Link) {
+				link, externalURL = c, item.Link
+			}
+		}
+
+		id := entry.GenerateID(link, idDate)
+		if guid := strings.TrimSpace(item.GUID); a.config.PreferGUID && guid != "" {
+			id = entry.GenerateGUIDID(outline.XMLURL, guid)
 		}
 
 		e := entry.Entry{
-			ID:      entry.GenerateID(item.Link, pubDate),
-			Title:   item.Title,
-			URL:     item.Link,
-			Author:  author,
-			Date:    pubDate,
-			Feed:    feedMeta,
-			Tags:    uniqueStrings(tags),
-			Summary: summary,
-			Content: content,
+			ID:          id,
+			Title:       item.Title,
+			URL:         link,
+			ExternalURL: externalURL,
+			Author:      author,
+			Language:    language,
+			Date:        pubDate,
+			Feed:        feedMeta,
+			Tags:        uniqueStrings(tags),
+			OutlineTags: outlineTags,
+			Summary:     summary,
+			Content:     content,
+		}
+		e.DateClamped = clamped
+		e.ContentTruncated = truncated
+		e.WordCount = wordCount
+		e.Image, e.ImageAlt = entryImage(item, summary, content)
+		if e.Image != "" && a.config.FetchImageDimensions {
+			e.ImageMeta = a.imageMeta(pageCtx, e.Image)
+		}
+		if jsonItems != nil {
+			applySignalExtensions(&e, jsonItems[i])
+		}
+		if a.config.IncludeRaw {
+			e.Raw = rawItem(item)
+		}
+		if a.config.EntryHook != nil {
+			a.config.EntryHook(&e)
 		}
 		result.Entries = append(result.Entries, e)
 	}
@@ -150,6 +402,109 @@ func (a *Aggregator) FetchFeed(ctx context.Context, outline opml.Outline) FetchR
 	return result
 }
 
+// sameURL reports whether two URLs are equal ignoring case and a trailing
+// slash, as deduplication compares them.
+func sameURL(a, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
+
+// feedFormat names the format and version of a parsed feed.
+func feedFormat(feed *gofeed.Feed) string {
+	var name, version string
+	switch feed.FeedType {
+	case "rss":
+		name, version = "RSS", feed.FeedVersion
+	case "atom":
+		name, version = "Atom", feed.FeedVersion
+	case "json":
+		// JSON Feed versions are URLs like https://jsonfeed.org/version/1.1
+		name, version = "JSON Feed", path.Base(feed.FeedVersion)
+		if version == "1" {
+			version = "1.0"
+		}
+	default:
+		return feed.FeedType
+	}
+	if version == "" || version == "." {
+		return name
+	}
+	return name + " " + version
+}
+
+// fetch retrieves the outline's feed URL and parses the response body.
+// The outline's UserAgent takes precedence over the configured one, and its
+// Accept over DefaultAccept.
+// JSON Feeds are parsed with the jsonfeed package, which also reads 1.0
+// feeds and Signal's extensions; their items are returned alongside the
+// converted feed, in the same order. For RSS and Atom they are nil.
+func (a *Aggregator) fetch(ctx context.Context, outline opml.Outline) (*gofeed.Feed, []jsonfeed.Item, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, outline.XMLURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	userAgent := a.config.UserAgent
+	if outline.UserAgent != "" {
+		userAgent = outline.UserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+	accept := DefaultAccept
+	if outline.Accept != "" {
+		accept = outline.Accept
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// An HTML page that doesn't look like a feed is usually a "feed moved"
+	// or error page served with 200; report it distinctly from parse errors.
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	feedType := gofeed.DetectFeedType(bytes.NewReader(body))
+	if mediaType == "text/html" && feedType == gofeed.FeedTypeUnknown {
+		return nil, nil, &FetchError{
+			Category:    NotAFeed,
+			URL:         resp.Request.URL.String(),
+			ContentType: contentType,
+		}
+	}
+
+	if feedType == gofeed.FeedTypeJSON || mediaType == jsonFeedMediaType {
+		jf, _, err := jsonfeed.Parse(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		feed := fromJSONFeed(jf)
+		// Parse normalizes the version to 1.1; report the declared one
+		var declared struct {
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(body, &declared) == nil && declared.Version != "" {
+			feed.FeedVersion = declared.Version
+		}
+		return feed, jf.Items, nil
+	}
+
+	// A gofeed.Parser is not safe for concurrent use
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(body))
+	return feed, nil, err
+}
+
 // ProgressFunc is called when a feed fetch completes.
 // current is the number of feeds fetched so far, total is the total number.
 // name is the feed title, entries is the number of entries fetched (0 if error).
@@ -160,68 +515,162 @@ func (a *Aggregator) FetchAll(ctx context.Context, o *opml.OPML) (*entry.Feed, [
 	return a.FetchAllWithProgress(ctx, o, nil)
 }
 
-// FetchAllWithProgress fetches all feeds with progress reporting.
+// FetchAllWithProgress fetches all feeds with progress reporting. If
+// outlines nest deeper than o.MaxDepth, the feeds within reach are still
+// fetched and the errors begin with the opml.CheckDepth error.
 func (a *Aggregator) FetchAllWithProgress(ctx context.Context, o *opml.OPML, progress ProgressFunc) (*entry.Feed, []error) {
-	feeds := o.FlattenFeeds()
+	depthErr := o.CheckDepth()
+	results := a.FetchResults(ctx, o.FlattenUniqueFeeds(), progress)
+	feed, errs := a.Combine(o.Title, results)
+	if depthErr != nil {
+		errs = append([]error{depthErr}, errs...)
+	}
+	return feed, errs
+}
 
-	results := make(chan FetchResult, len(feeds))
-	sem := make(chan struct{}, a.config.Concurrency)
+// FetchResults fetches the given feeds concurrently and returns one result
+// per feed in completion order. Use Combine to build a feed from them.
+// With Config.Concurrency 1, feeds are fetched sequentially in order.
+// With Config.Checkpoint, feeds with a saved result are not refetched.
+func (a *Aggregator) FetchResults(ctx context.Context, feeds []opml.Outline, progress ProgressFunc) []FetchResult {
+	total := len(feeds)
+	if a.config.Concurrency == 1 {
+		var results []FetchResult
+		for _, outline := range feeds {
+			result := a.fetchCheckpointed(ctx, outline)
+			results = append(results, result)
+			if progress != nil {
+				progress(len(results), total, result.Outline.Title, len(result.Entries), result.Error)
+			}
+		}
+		return results
+	}
+
+	resultsCh := make(chan FetchResult, len(feeds))
+	var sem chan struct{} // nil (unbounded) when Concurrency <= 0
+	if a.config.Concurrency > 0 {
+		sem = make(chan struct{}, a.config.Concurrency)
+	}
 
 	var wg sync.WaitGroup
 	for _, outline := range feeds {
 		wg.Add(1)
 		go func(out opml.Outline) {
 			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-			results <- a.FetchFeed(ctx, out)
+			if sem != nil {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+			}
+			resultsCh <- a.fetchCheckpointed(ctx, out)
 		}(outline)
 	}
 
 	go func() {
 		wg.Wait()
-		close(results)
+		close(resultsCh)
 	}()
 
-	feed := entry.NewFeed(o.Title, "", "")
-	var errors []error
-	completed := 0
-	total := len(feeds)
+	var results []FetchResult
+	for result := range resultsCh {
+		results = append(results, result)
+		if progress != nil {
+			progress(len(results), total, result.Outline.Title, len(result.Entries), result.Error)
+		}
+	}
+
+	return results
+}
+
+// fetchCheckpointed returns the checkpointed result for a feed if there is
+// one, and otherwise fetches it and checkpoints the result.
+func (a *Aggregator) fetchCheckpointed(ctx context.Context, outline opml.Outline) FetchResult {
+	cp := a.config.Checkpoint
+	if cp == nil {
+		return a.FetchFeed(ctx, outline)
+	}
+	if result, ok := cp.Lookup(outline.XMLURL); ok {
+		return result
+	}
+	result := a.FetchFeed(ctx, outline)
+	cp.Save(result)
+	return result
+}
+
+// SlowestFirst returns a copy of results sorted by fetch duration, slowest
+// first.
+func SlowestFirst(results []FetchResult) []FetchResult {
+	sorted := append([]FetchResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+	return sorted
+}
+
+// Combine builds a deduplicated, date-sorted feed from fetch results,
+// returning the errors of any failed fetches.
+func (a *Aggregator) Combine(title string, results []FetchResult) (*entry.Feed, []error) {
+	feed, errs, _ := a.CombineWithReport(title, results)
+	return feed, errs
+}
+
+// CombineWithReport is like Combine but also reports which entries were
+// collapsed by URL deduplication, showing where sources overlap.
+func (a *Aggregator) CombineWithReport(title string, results []FetchResult) (*entry.Feed, []error, []entry.DuplicateGroup) {
+	feed := entry.NewFeed(title, "", "")
+	if !a.config.GeneratedAt.IsZero() {
+		feed.Generated = a.config.GeneratedAt.UTC()
+	}
+	var errs []error
 
-	for result := range results {
-		completed++
+	for _, result := range results {
 		if result.Error != nil {
-			errors = append(errors, result.Error)
-			if progress != nil {
-				progress(completed, total, result.Outline.Title, 0, result.Error)
-			}
+			errs = append(errs, result.Error)
 			continue
 		}
 		for _, e := range result.Entries {
 			feed.AddEntry(e)
 		}
-		if progress != nil {
-			progress(completed, total, result.Outline.Title, len(result.Entries), nil)
-		}
 	}
 
-	feed.Deduplicate()
+	dupes := feed.DeduplicateScoped(a.config.DedupScope)
+	if a.config.DedupByTitle {
+		feed.DeduplicateByTitle(entry.DefaultTitleDedupWindow)
+	}
+	if a.config.DedupBySummary {
+		feed.DedupeBySummary()
+	}
 	feed.SortByDate()
 
-	return feed, errors
+	return feed, errs, dupes
 }
 
-// truncateHTML truncates HTML content to approximately n characters.
-func truncateHTML(s string, n int) string {
-	if len(s) <= n {
-		return s
+// rawItem snapshots the original feed item fields most useful when
+// diagnosing how an entry was built. Empty fields are omitted.
+func rawItem(item *gofeed.Item) map[string]any {
+	raw := make(map[string]any)
+	for key, value := range map[string]string{
+		"guid":      item.GUID,
+		"link":      item.Link,
+		"published": item.Published,
+		"updated":   item.Updated,
+	} {
+		if value != "" {
+			raw[key] = value
+		}
 	}
-	// Simple truncation - a proper implementation would handle HTML tags
-	truncated := s[:n]
-	if idx := strings.LastIndex(truncated, " "); idx > n/2 {
-		truncated = truncated[:idx]
+	if len(item.Categories) > 0 {
+		raw["categories"] = item.Categories
+	}
+	if len(item.Authors) > 0 {
+		var authors []string
+		for _, a := range item.Authors {
+			if a != nil {
+				authors = append(authors, a.Name)
+			}
+		}
+		raw["authors"] = authors
 	}
-	return truncated + "..."
+	return raw
 }
 
 // uniqueStrings returns unique strings, preserving order.
@@ -0,0 +1,284 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grokify/signal/opml"
+)
+
+const testRSS = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Test Blog</title><link>https://example.com/</link>
+<item><title>Hello</title><link>https://example.com/hello</link><pubDate>Mon, 05 Oct 2026 10:00:00 GMT</pubDate></item>
+</channel></rss>`
+
+// serveRSS returns a test server serving testRSS, recording each request.
+func serveRSS(t *testing.T, record func(*http.Request)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if record != nil {
+			record(r)
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(testRSS))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchFeedUserAgent(t *testing.T) {
+	var got string
+	srv := serveRSS(t, func(r *http.Request) { got = r.Header.Get("User-Agent") })
+
+	cfg := DefaultConfig()
+	cfg.UserAgent = "Global/1.0"
+	a := New(cfg)
+
+	tests := []struct {
+		name    string
+		outline string
+		want    string
+	}{
+		{"global", "", "Global/1.0"},
+		{"outline override", "PerFeed/2.0", "PerFeed/2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := a.FetchFeed(context.Background(), opml.Outline{XMLURL: srv.URL, UserAgent: tt.outline})
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			if got != tt.want {
+				t.Errorf("User-Agent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchAllSkipsDisabled(t *testing.T) {
+	var mu sync.Mutex
+	fetched := make(map[string]bool)
+	srv := serveRSS(t, func(r *http.Request) {
+		mu.Lock()
+		fetched[r.URL.Path] = true
+		mu.Unlock()
+	})
+
+	cfg := DefaultConfig()
+	cfg.RespectRobots = false
+	a := New(cfg)
+
+	o := &opml.OPML{Outlines: []opml.Outline{
+		{Text: "Enabled", XMLURL: srv.URL + "/enabled"},
+		{Text: "Disabled", XMLURL: srv.URL + "/disabled", Disabled: true},
+		{Text: "Disabled group", Disabled: true, Outlines: []opml.Outline{
+			{Text: "Nested", XMLURL: srv.URL + "/nested"},
+		}},
+	}}
+	feed, errs := a.FetchAll(context.Background(), o)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if len(feed.Entries) != 1 {
+		t.Errorf("got %d entries, want 1", len(feed.Entries))
+	}
+	for _, path := range []string{"/disabled", "/nested"} {
+		if fetched[path] {
+			t.Errorf("%s was fetched", path)
+		}
+	}
+	if !fetched["/enabled"] {
+		t.Error("/enabled was not fetched")
+	}
+}
+
+// concurrencyFeeds returns n outlines on srv with distinct paths.
+func concurrencyFeeds(srv *httptest.Server, n int) []opml.Outline {
+	feeds := make([]opml.Outline, n)
+	for i := range feeds {
+		feeds[i] = opml.Outline{Title: fmt.Sprintf("feed%d", i), XMLURL: fmt.Sprintf("%s/feed%d", srv.URL, i)}
+	}
+	return feeds
+}
+
+func TestFetchResultsSequential(t *testing.T) {
+	var (
+		mu                sync.Mutex
+		order             []string
+		inFlight, maxLoad int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		order = append(order, r.URL.Path)
+		inFlight++
+		maxLoad = max(maxLoad, inFlight)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(testRSS))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.Concurrency = 1
+	feeds := concurrencyFeeds(srv, 5)
+	results := New(cfg).FetchResults(context.Background(), feeds, nil)
+
+	if maxLoad != 1 {
+		t.Errorf("%d fetches ran at once, want 1", maxLoad)
+	}
+	for i, result := range results {
+		if want := feeds[i].XMLURL; result.Outline.XMLURL != want {
+			t.Errorf("results[%d] is %s, want %s", i, result.Outline.XMLURL, want)
+		}
+		if want := fmt.Sprintf("/feed%d", i); order[i] != want {
+			t.Errorf("request %d was %s, want %s", i, order[i], want)
+		}
+	}
+}
+
+func TestFetchResultsUnbounded(t *testing.T) {
+	const n = 20
+	var (
+		mu      sync.Mutex
+		arrived int
+	)
+	all := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrived++
+		if arrived == n {
+			close(all)
+		}
+		mu.Unlock()
+		// Every fetch must be in flight at once for any to finish
+		select {
+		case <-all:
+		case <-time.After(5 * time.Second):
+			http.Error(w, "not all fetches started", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(testRSS))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.Concurrency = 0
+	results := New(cfg).FetchResults(context.Background(), concurrencyFeeds(srv, n), nil)
+	if len(results) != n {
+		t.Fatalf("got %d results, want %d", len(results), n)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("%s: %v", result.Outline.XMLURL, result.Error)
+		}
+	}
+}
+
+func TestFetchFeedTitlePrecedence(t *testing.T) {
+	srv := serveRSS(t, nil)
+
+	tests := []struct {
+		name          string
+		titleOverride bool
+		preferOPML    bool
+		outlineTitle  string
+		want          string
+	}{
+		{"feed title by default", false, false, "Curated Name", "Test Blog"},
+		{"outline TitleOverride", true, false, "Curated Name", "Curated Name"},
+		{"Config.PreferOPMLTitle", false, true, "Curated Name", "Curated Name"},
+		{"no outline title to prefer", false, true, "", "Test Blog"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.PreferOPMLTitle = tt.preferOPML
+			outline := opml.Outline{XMLURL: srv.URL, Title: tt.outlineTitle, TitleOverride: tt.titleOverride}
+			result := New(cfg).FetchFeed(context.Background(), outline)
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			if result.Feed.Title != tt.want {
+				t.Errorf("Feed.Title = %q, want %q", result.Feed.Title, tt.want)
+			}
+			for _, e := range result.Entries {
+				if e.Feed.Title != tt.want {
+					t.Errorf("entry Feed.Title = %q, want %q", e.Feed.Title, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchFeedAccept(t *testing.T) {
+	// Serves testRSS only to clients that accept RSS, like feeds behind
+	// strict content negotiation
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept := r.Header.Get("Accept")
+		if !strings.Contains(accept, "application/rss+xml") && !strings.Contains(accept, "*/*") {
+			http.Error(w, "Not Acceptable", http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(testRSS))
+	}))
+	t.Cleanup(srv.Close)
+
+	a := New(DefaultConfig())
+	tests := []struct {
+		name    string
+		accept  string
+		wantErr bool
+	}{
+		{"default", "", false},
+		{"outline override", "application/rss+xml", false},
+		{"outline override the server rejects", "text/html", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := a.FetchFeed(context.Background(), opml.Outline{XMLURL: srv.URL, Accept: tt.accept})
+			if gotErr := result.Error != nil; gotErr != tt.wantErr {
+				t.Fatalf("Error = %v, want error %v", result.Error, tt.wantErr)
+			}
+			if !tt.wantErr && len(result.Entries) != 1 {
+				t.Errorf("got %d entries, want 1", len(result.Entries))
+			}
+		})
+	}
+
+	// Without an Accept header at all, the server refuses
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotAcceptable {
+		t.Errorf("request without Accept: status %d, want %d", resp.StatusCode, http.StatusNotAcceptable)
+	}
+}
+
+func TestFetchAllReportsDepth(t *testing.T) {
+	srv := serveRSS(t, nil)
+	o := &opml.OPML{MaxDepth: 1, Outlines: []opml.Outline{{
+		Text:     "Shallow",
+		XMLURL:   srv.URL + "/shallow",
+		Outlines: []opml.Outline{{Text: "Deep", XMLURL: srv.URL + "/deep"}},
+	}}}
+
+	feed, errs := New(DefaultConfig()).FetchAll(context.Background(), o)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `"Deep"`) {
+		t.Errorf("errors = %v, want the depth error naming the skipped outline", errs)
+	}
+	if len(feed.Entries) != 1 {
+		t.Errorf("got %d entries, want the 1 from the reachable feed", len(feed.Entries))
+	}
+}
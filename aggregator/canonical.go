@@ -0,0 +1,90 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// canonicalURL returns the canonical URL of the article at link, taken
+// from a <link rel="canonical"> or og:url in content if present, and
+// otherwise from the article page, fetched through FetchPage. It returns
+// "" if none is found.
+func (a *Aggregator) canonicalURL(ctx context.Context, link, content string) string {
+	if c := findCanonicalURL([]byte(content), link); c != "" {
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	body, err := a.FetchPage(ctx, link)
+	if err != nil {
+		return ""
+	}
+	return findCanonicalURL(body, link)
+}
+
+// findCanonicalURL returns the href of the first <link rel="canonical">
+// in an HTML document or fragment, or failing that its og:url meta tag,
+// resolved against base. Only absolute http(s) URLs are returned.
+func findCanonicalURL(page []byte, base string) string {
+	var canonical, ogURL string
+	z := html.NewTokenizer(bytes.NewReader(page))
+	for done := false; !done && canonical == ""; {
+		switch z.Next() {
+		case html.ErrorToken:
+			done = true
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var k, v []byte
+				k, v, hasAttr = z.TagAttr()
+				attrs[string(k)] = strings.TrimSpace(string(v))
+			}
+			switch string(name) {
+			case "body":
+				done = true
+			case "link":
+				if hasToken(strings.ToLower(attrs["rel"]), "canonical") {
+					canonical = resolveHTTPURL(attrs["href"], base)
+				}
+			case "meta":
+				if ogURL == "" && strings.EqualFold(attrs["property"], "og:url") {
+					ogURL = resolveHTTPURL(attrs["content"], base)
+				}
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				done = true
+			}
+		}
+	}
+	if canonical != "" {
+		return canonical
+	}
+	return ogURL
+}
+
+// resolveHTTPURL resolves ref against base and returns it if the result
+// is an absolute http or https URL, else "".
+func resolveHTTPURL(ref, base string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if b, err := url.Parse(base); err == nil {
+		u = b.ResolveReference(u)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,178 @@
+package aggregator
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/opml"
+)
+
+// Checkpoint records successful fetch results as they complete so an
+// interrupted aggregation can resume without refetching them. Results are
+// appended to the file as newline-delimited JSON keyed by feed URL; a line
+// cut short by a crash is dropped on resume. A Checkpoint is safe for
+// concurrent use.
+type Checkpoint struct {
+	filename string
+	mu       sync.Mutex
+	file     *os.File
+	done     map[string]FetchResult // feed URL -> saved result
+	err      error                  // first save error
+}
+
+// checkpointRecord is the stored form of a successful FetchResult.
+type checkpointRecord struct {
+	URL         string         `json:"url"`
+	Outline     opml.Outline   `json:"outline"`
+	Feed        entry.FeedMeta `json:"feed"`
+	Format      string         `json:"format,omitempty"`
+	DurationMS  int64          `json:"durationMs"`
+	BogusDates  int            `json:"bogusDates,omitempty"`
+	FutureDates int            `json:"futureDates,omitempty"`
+	Entries     []entry.Entry  `json:"entries"`
+}
+
+// OpenCheckpoint opens the checkpoint file, creating it if needed. With
+// resume, results saved by an earlier run are loaded for Lookup and new
+// results are appended after dropping any line a crash cut short;
+// otherwise the file is truncated.
+func OpenCheckpoint(filename string, resume bool) (*Checkpoint, error) {
+	c := &Checkpoint{filename: filename, done: make(map[string]FetchResult)}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	var complete int64
+	if resume {
+		var err error
+		if complete, err = c.load(); err != nil {
+			return nil, err
+		}
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(filename, flag, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if resume {
+		// Otherwise the next record would continue the partial line
+		if err := f.Truncate(complete); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	c.file = f
+	return c, nil
+}
+
+// load reads the results saved in the checkpoint file and returns the
+// length of its complete, newline-terminated lines.
+func (c *Checkpoint) load() (int64, error) {
+	f, err := os.Open(c.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var complete int64
+	r := bufio.NewReaderSize(f, 64*1024)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return complete, nil // a partial last line is dropped
+		} else if err != nil {
+			return 0, err
+		}
+		complete += int64(len(line))
+		var rec checkpointRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			continue // corrupt line from an interrupted write
+		}
+		c.done[rec.URL] = FetchResult{
+			Outline:     rec.Outline,
+			Entries:     rec.Entries,
+			Duration:    time.Duration(rec.DurationMS) * time.Millisecond,
+			Format:      rec.Format,
+			Feed:        rec.Feed,
+			BogusDates:  rec.BogusDates,
+			FutureDates: rec.FutureDates,
+			Resumed:     true,
+		}
+	}
+}
+
+// Len returns the number of results loaded from an earlier run.
+func (c *Checkpoint) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.done)
+}
+
+// Lookup returns the saved result for a feed URL.
+func (c *Checkpoint) Lookup(feedURL string) (FetchResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.done[feedURL]
+	return r, ok
+}
+
+// Save appends a successful result to the checkpoint. Failed and resumed
+// results are ignored. Write errors are kept and returned by Close so a
+// broken checkpoint never fails the fetch itself.
+func (c *Checkpoint) Save(r FetchResult) {
+	if r.Error != nil || r.Resumed {
+		return
+	}
+	data, err := json.Marshal(checkpointRecord{
+		URL:         r.Outline.XMLURL,
+		Outline:     r.Outline,
+		Feed:        r.Feed,
+		Format:      r.Format,
+		DurationMS:  r.Duration.Milliseconds(),
+		BogusDates:  r.BogusDates,
+		FutureDates: r.FutureDates,
+		Entries:     r.Entries,
+	})
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return
+	}
+	if err == nil {
+		_, err = c.file.Write(append(data, '\n'))
+	}
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// Close closes the checkpoint file and returns the first save error.
+// Closing more than once is a no-op.
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.Close()
+	c.file = nil
+	if c.err != nil {
+		return c.err
+	}
+	return err
+}
+
+// Remove closes and deletes the checkpoint, e.g., after a run completes.
+func (c *Checkpoint) Remove() error {
+	_ = c.Close()
+	err := os.Remove(c.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
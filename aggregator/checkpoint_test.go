@@ -0,0 +1,64 @@
+package aggregator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/opml"
+)
+
+func checkpointResult(feedURL string) FetchResult {
+	return FetchResult{
+		Outline: opml.Outline{XMLURL: feedURL},
+		Entries: []entry.Entry{{ID: "1", URL: feedURL + "/post"}},
+	}
+}
+
+func TestCheckpointResumeAfterPartialLine(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "checkpoint.ndjson")
+
+	cp, err := OpenCheckpoint(filename, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.Save(checkpointResult("https://a.example.com/feed"))
+	if err := cp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A crash mid-write leaves a partial last line
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"url":"https://b.example.com/feed","outline":{"xmlU`)
+	f.Close()
+
+	cp, err = OpenCheckpoint(filename, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Len() != 1 {
+		t.Fatalf("resumed %d results, want 1", cp.Len())
+	}
+	cp.Save(checkpointResult("https://c.example.com/feed"))
+	if err := cp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err = OpenCheckpoint(filename, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+	for _, feedURL := range []string{"https://a.example.com/feed", "https://c.example.com/feed"} {
+		if _, ok := cp.Lookup(feedURL); !ok {
+			t.Errorf("%s not resumed", feedURL)
+		}
+	}
+	if cp.Len() != 2 {
+		t.Errorf("resumed %d results, want 2", cp.Len())
+	}
+}
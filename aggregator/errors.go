@@ -0,0 +1,36 @@
+package aggregator
+
+import "fmt"
+
+// ErrorCategory classifies why a feed fetch failed.
+type ErrorCategory string
+
+const (
+	// NotAFeed means the URL returned an HTML page rather than a feed,
+	// typically a "this feed has moved" or error page served with 200 OK.
+	NotAFeed ErrorCategory = "not_a_feed"
+)
+
+// FetchError is a categorized feed fetch failure.
+type FetchError struct {
+	Category    ErrorCategory
+	URL         string // Final URL after redirects
+	ContentType string // Response Content-Type
+	Err         error  // Underlying error, if any
+}
+
+func (e *FetchError) Error() string {
+	switch e.Category {
+	case NotAFeed:
+		return fmt.Sprintf("not a feed: %s returned content type %q", e.URL, e.ContentType)
+	default:
+		if e.Err != nil {
+			return fmt.Sprintf("%s: %s: %v", e.Category, e.URL, e.Err)
+		}
+		return fmt.Sprintf("%s: %s", e.Category, e.URL)
+	}
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,52 @@
+package aggregator
+
+import (
+	"github.com/grokify/signal/opml"
+)
+
+// ExportOptions controls ExportOPML.
+type ExportOptions struct {
+	// ResolveMeta replaces each outline's title and home page URL with
+	// those declared by the fetched feed, when present.
+	ResolveMeta bool
+	// DisableFailed keeps feeds that failed to fetch, marked disabled,
+	// instead of dropping them.
+	DisableFailed bool
+}
+
+// ExportOPML builds a flat OPML feed list from feeds, in order, keeping
+// only feeds whose result shows they fetched successfully unless
+// opts.DisableFailed is set. Feeds without a result are omitted. Use it to
+// prune dead feeds from a list. The caller sets the document title, owner,
+// and dates.
+func ExportOPML(feeds []opml.Outline, results []FetchResult, opts ExportOptions) *opml.OPML {
+	byURL := make(map[string]FetchResult, len(results))
+	for _, r := range results {
+		byURL[r.Outline.XMLURL] = r
+	}
+
+	o := &opml.OPML{Version: "2.0", Outlines: []opml.Outline{}}
+	for _, f := range feeds {
+		r, ok := byURL[f.XMLURL]
+		if !ok {
+			continue
+		}
+		outline := r.Outline
+		if r.Error != nil {
+			if !opts.DisableFailed {
+				continue
+			}
+			outline.Disabled = true
+		} else if opts.ResolveMeta {
+			if r.Feed.Title != "" {
+				outline.Title = r.Feed.Title
+			}
+			if r.Feed.URL != "" {
+				outline.HTMLURL = r.Feed.URL
+			}
+		}
+		outline.Outlines = nil
+		o.Outlines = append(o.Outlines, outline)
+	}
+	return o
+}
@@ -0,0 +1,132 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// faviconEntry caches the favicon lookup for one host.
+type faviconEntry struct {
+	done chan struct{} // Closed once url is set
+	url  string
+}
+
+// favicon returns an icon URL for the site at siteURL, or "" if none is
+// found. The site's home page is checked for <link rel="icon">, falling
+// back to /favicon.ico when the server has one. Each lookup gets its own
+// Config.Timeout. Lookups are cached per host for the Aggregator's
+// lifetime, except those cut short by their context, and go through
+// FetchPage, so they honor robots.txt and the crawl delay.
+func (a *Aggregator) favicon(ctx context.Context, siteURL string) string {
+	u, err := url.Parse(siteURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	a.faviconsMu.Lock()
+	fe, ok := a.favicons[u.Host]
+	if !ok {
+		fe = &faviconEntry{done: make(chan struct{})}
+		a.favicons[u.Host] = fe
+	}
+	a.faviconsMu.Unlock()
+	if ok {
+		select {
+		case <-fe.done:
+			return fe.url
+		case <-ctx.Done():
+			return ""
+		}
+	}
+
+	fe.url = a.lookupFavicon(ctx, u)
+	if fe.url == "" && ctx.Err() != nil {
+		// Not the site's answer, so a later feed from the host tries again
+		a.faviconsMu.Lock()
+		delete(a.favicons, u.Host)
+		a.faviconsMu.Unlock()
+	}
+	close(fe.done)
+	return fe.url
+}
+
+func (a *Aggregator) lookupFavicon(ctx context.Context, u *url.URL) string {
+	home := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}
+	if body, err := a.FetchPage(ctx, home.String()); err == nil {
+		if href := findIconLink(body); href != "" {
+			if ref, err := home.Parse(href); err == nil {
+				return ref.String()
+			}
+		}
+	}
+
+	ico := home.JoinPath("favicon.ico").String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, ico, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("User-Agent", a.config.UserAgent)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return ""
+	}
+	return ico
+}
+
+// findIconLink returns the href of the first <link rel="icon"> (including
+// "shortcut icon") in an HTML document's head.
+func findIconLink(page []byte) string {
+	z := html.NewTokenizer(bytes.NewReader(page))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return ""
+			case "link":
+				var rel, href string
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					switch string(key) {
+					case "rel":
+						rel = strings.ToLower(string(val))
+					case "href":
+						href = strings.TrimSpace(string(val))
+					}
+				}
+				if href != "" && hasToken(rel, "icon") {
+					return href
+				}
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return ""
+			}
+		}
+	}
+}
+
+// hasToken reports whether a space-separated attribute value contains token.
+func hasToken(value, token string) bool {
+	for _, f := range strings.Fields(value) {
+		if f == token {
+			return true
+		}
+	}
+	return false
+}
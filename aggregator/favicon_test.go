@@ -0,0 +1,32 @@
+package aggregator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFaviconNotCachedAfterContextFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Write([]byte(`<html><head><link rel="icon" href="/icon.png"></head></html>`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.CrawlDelay = 0
+	a := New(cfg)
+
+	expired, cancel := context.WithCancel(context.Background())
+	cancel()
+	if icon := a.favicon(expired, srv.URL); icon != "" {
+		t.Fatalf("with an expired context: icon = %q, want none", icon)
+	}
+	if icon, want := a.favicon(context.Background(), srv.URL), srv.URL+"/icon.png"; icon != want {
+		t.Errorf("after an expired context: icon = %q, want %q", icon, want)
+	}
+}
@@ -0,0 +1,52 @@
+package aggregator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultFutureSkew is how far ahead of now an entry may be dated before
+// Config.FutureDates treats it as future-dated, allowing for clock skew
+// and time zone mistakes.
+const DefaultFutureSkew = time.Hour
+
+// FutureDatePolicy controls what FetchFeed does with entries dated in the
+// future, which would otherwise stay at the top of newest-first output.
+type FutureDatePolicy int
+
+const (
+	// FutureKeep keeps future-dated entries as they are.
+	FutureKeep FutureDatePolicy = iota
+	// FutureDrop drops future-dated entries.
+	FutureDrop
+	// FutureClamp keeps future-dated entries, dated at fetch time. Their
+	// IDs still derive from the feed's date so they stay stable, and
+	// entry.MergeEntries keeps the date of a stored copy, so an entry is
+	// clamped once rather than returning to the top on every run.
+	FutureClamp
+)
+
+var futureDatePolicyNames = map[FutureDatePolicy]string{
+	FutureKeep:  "keep",
+	FutureDrop:  "drop",
+	FutureClamp: "clamp",
+}
+
+// String returns the CLI name of the policy.
+func (p FutureDatePolicy) String() string {
+	if name, ok := futureDatePolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("FutureDatePolicy(%d)", int(p))
+}
+
+// ParseFutureDatePolicy parses a policy name: "keep", "drop", or "clamp".
+func ParseFutureDatePolicy(name string) (FutureDatePolicy, error) {
+	for p, n := range futureDatePolicyNames {
+		if strings.EqualFold(name, n) {
+			return p, nil
+		}
+	}
+	return FutureKeep, fmt.Errorf("unknown future date policy %q (want keep, drop, or clamp)", name)
+}
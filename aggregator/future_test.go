@@ -0,0 +1,56 @@
+package aggregator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/opml"
+)
+
+const futureRSS = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Future</title><link>https://example.com/</link>
+<item><title>Ahead</title><link>https://example.com/ahead</link><pubDate>Fri, 01 Jan 2100 00:00:00 GMT</pubDate></item>
+</channel></rss>`
+
+func TestFutureClampKeepsStoredDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(futureRSS))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.FutureDates = FutureClamp
+	outline := opml.Outline{Title: "Future", XMLURL: srv.URL}
+
+	var stored []entry.Entry
+	var firstDate time.Time
+	for run := 1; run <= 2; run++ {
+		result := New(cfg).FetchFeed(context.Background(), outline)
+		if result.Error != nil {
+			t.Fatalf("run %d: %v", run, result.Error)
+		}
+		if result.FutureDates != 1 || len(result.Entries) != 1 {
+			t.Fatalf("run %d: got %d entries, %d future-dated; want 1, 1", run, len(result.Entries), result.FutureDates)
+		}
+		if d := result.Entries[0].Date; d.Year() == 2100 {
+			t.Fatalf("run %d: date %v was not clamped", run, d)
+		}
+		stored = entry.MergeEntries(stored, result.Entries, entry.NewestWins, entry.DedupGlobal)
+		if run == 1 {
+			firstDate = stored[0].Date
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(stored) != 1 {
+		t.Fatalf("got %d stored entries, want 1", len(stored))
+	}
+	if !stored[0].Date.Equal(firstDate) {
+		t.Errorf("second run moved the date from %v to %v", firstDate, stored[0].Date)
+	}
+}
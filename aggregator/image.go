@@ -0,0 +1,86 @@
+package aggregator
+
+import (
+	"context"
+	"image"
+	_ "image/gif" // register decoders for image.DecodeConfig
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/grokify/signal/entry"
+	"github.com/mmcdole/gofeed"
+)
+
+// imageHeaderBytes is how much of an image imageMeta reads, enough for the
+// headers of GIF, PNG, and most JPEG files.
+const imageHeaderBytes = 64 << 10
+
+// entryImage picks an entry's image: the item's own image, else an image
+// enclosure, else the first image in its content or summary. The alt text
+// comes from the matching <img> in the content or summary, if any. Since
+// gofeed takes an RSS item's image from the first <img> in its
+// description, one that is there only as a tracking pixel is passed over.
+func entryImage(item *gofeed.Item, summary, content string) (src, alt string) {
+	if item.Image != nil {
+		src = strings.TrimSpace(item.Image.URL)
+	}
+	if src == "" {
+		for _, enc := range item.Enclosures {
+			if strings.HasPrefix(enc.Type, "image/") && enc.URL != "" {
+				src = enc.URL
+				break
+			}
+		}
+	}
+	for _, s := range []string{content, summary} {
+		if found, foundAlt := entry.FindImage(s, src); found != "" {
+			return found, foundAlt
+		}
+		if src != "" && strings.Contains(s, src) {
+			src = "" // a tracking pixel; take the first real image instead
+			if found, foundAlt := entry.FindImage(s, ""); found != "" {
+				return found, foundAlt
+			}
+		}
+	}
+	return src, ""
+}
+
+// imageMeta returns the dimensions of the image at imageURL, decoded from
+// the first imageHeaderBytes of it fetched with a ranged GET. It returns
+// nil if the image can't be fetched or isn't a GIF, JPEG, or PNG.
+func (a *Aggregator) imageMeta(ctx context.Context, imageURL string) *entry.ImageMeta {
+	u, err := url.Parse(imageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", a.config.UserAgent)
+	req.Header.Set("Range", "bytes=0-"+strconv.Itoa(imageHeaderBytes-1))
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil
+	}
+
+	cfg, _, err := image.DecodeConfig(io.LimitReader(resp.Body, imageHeaderBytes))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil
+	}
+	return &entry.ImageMeta{Width: cfg.Width, Height: cfg.Height}
+}
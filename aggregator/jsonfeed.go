@@ -0,0 +1,120 @@
+package aggregator
+
+import (
+	"html"
+	"time"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/jsonfeed"
+	"github.com/mmcdole/gofeed"
+)
+
+// jsonFeedMediaType is the registered media type for JSON Feed.
+const jsonFeedMediaType = "application/feed+json"
+
+// fromJSONFeed converts a JSON Feed parsed by jsonfeed.Parse into a gofeed
+// feed, so it goes through the same entry building as RSS and Atom. Items
+// keep their order, so jf.Items[i] is the source of the result's Items[i].
+func fromJSONFeed(jf *jsonfeed.Feed) *gofeed.Feed {
+	feed := &gofeed.Feed{
+		Title:       jf.Title,
+		Description: jf.Description,
+		Link:        jf.HomePageURL,
+		FeedLink:    jf.FeedURL,
+		FeedType:    "json",
+		FeedVersion: jf.Version,
+		Language:    jf.Language,
+	}
+	if jf.Icon != "" {
+		feed.Image = &gofeed.Image{URL: jf.Icon}
+	}
+
+	for _, it := range jf.Items {
+		item := &gofeed.Item{
+			GUID:        it.ID,
+			Title:       it.Title,
+			Link:        it.URL,
+			Description: it.Summary,
+			Content:     it.ContentHTML,
+			Published:   it.DatePublished,
+			Updated:     it.DateModified,
+			Categories:  it.Tags,
+		}
+		if item.Link == "" {
+			item.Link = it.ExternalURL
+		}
+		if item.Content == "" && it.ContentText != "" {
+			item.Content = html.EscapeString(it.ContentText)
+		}
+		item.PublishedParsed = parseJSONFeedDate(it.DatePublished)
+		item.UpdatedParsed = parseJSONFeedDate(it.DateModified)
+		for _, a := range it.Authors {
+			item.Authors = append(item.Authors, &gofeed.Person{Name: a.Name})
+		}
+		if len(item.Authors) > 0 {
+			item.Author = item.Authors[0]
+		}
+		if it.Image != "" {
+			item.Image = &gofeed.Image{URL: it.Image}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	return feed
+}
+
+func parseJSONFeedDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := jsonfeed.ParseDate(s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// applySignalExtensions copies the _signal_* extensions of a JSON Feed
+// item onto an entry built from it, so Signal can consume its own output
+// (or another planet's) without losing curation or discussion links.
+// The original source feed replaces the planet as the entry's feed. An
+// external_url alongside url is kept as the entry's ExternalURL, and an
+// item's language overrides the feed's.
+func applySignalExtensions(e *entry.Entry, it jsonfeed.Item) {
+	if it.URL != "" && it.ExternalURL != "" && e.ExternalURL == "" {
+		e.ExternalURL = it.ExternalURL
+	}
+	if it.Language != "" {
+		e.Language = it.Language
+	}
+	if it.SignalFeedTitle != "" {
+		e.Feed.Title = it.SignalFeedTitle
+		e.Feed.URL = it.SignalFeedURL
+	}
+	if it.SignalPriority {
+		e.IsPriority = true
+		e.PriorityRank = it.SignalRank
+		e.PriorityPeriod = it.SignalPriorityPeriod
+	}
+	for _, d := range it.SignalDiscussions {
+		e.Discussions = append(e.Discussions, entry.Discussion{
+			Platform: d.Platform,
+			URL:      d.URL,
+			ID:       d.ID,
+			Score:    d.Score,
+			Comments: d.Comments,
+		})
+	}
+	if it.SignalSource != nil {
+		e.Source = &entry.Source{
+			Platform: it.SignalSource.Platform,
+			Author:   it.SignalSource.Author,
+			PostID:   it.SignalSource.PostID,
+		}
+	}
+	if it.SignalImageAlt != "" && e.ImageAlt == "" {
+		e.ImageAlt = it.SignalImageAlt
+	}
+	if it.SignalImageMeta != nil && e.ImageMeta == nil {
+		e.ImageMeta = &entry.ImageMeta{Width: it.SignalImageMeta.Width, Height: it.SignalImageMeta.Height}
+	}
+}
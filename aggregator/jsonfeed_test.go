@@ -0,0 +1,59 @@
+package aggregator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grokify/signal/opml"
+)
+
+const testJSONFeed = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "JSON Blog",
+  "home_page_url": "https://json.example.com/",
+  "items": [{
+    "id": "1",
+    "url": "https://json.example.com/posts/1",
+    "title": "First Post",
+    "content_html": "<p>Hello from JSON Feed</p>",
+    "date_published": "2025-10-01T09:00:00Z",
+    "authors": [{"name": "Ada"}],
+    "tags": ["go"],
+    "_signal_discussions": [{"platform": "hackernews", "url": "https://news.ycombinator.com/item?id=1", "score": 42}]
+  }]
+}`
+
+func TestFetchFeedJSONFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		w.Write([]byte(testJSONFeed))
+	}))
+	t.Cleanup(srv.Close)
+
+	result := New(DefaultConfig()).FetchFeed(context.Background(), opml.Outline{XMLURL: srv.URL})
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	if result.Format != "JSON Feed 1.1" {
+		t.Errorf("Format = %q, want %q", result.Format, "JSON Feed 1.1")
+	}
+	if result.Feed.Title != "JSON Blog" {
+		t.Errorf("Feed.Title = %q, want %q", result.Feed.Title, "JSON Blog")
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(result.Entries))
+	}
+	e := result.Entries[0]
+	if e.Title != "First Post" || e.URL != "https://json.example.com/posts/1" || e.Author != "Ada" {
+		t.Errorf("entry = %q %q by %q, want the item's title, URL, and author", e.Title, e.URL, e.Author)
+	}
+	if want := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC); !e.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", e.Date, want)
+	}
+	if len(e.Discussions) != 1 || e.Discussions[0].Platform != "hackernews" || e.Discussions[0].Score != 42 {
+		t.Errorf("Discussions = %+v, want the item's _signal_discussions", e.Discussions)
+	}
+}
@@ -0,0 +1,73 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// metaSummary returns a summary for the article at pageURL taken from its
+// og:description, description, or twitter:description meta tag, in that
+// order of preference, escaped for use as HTML. It returns "" if the page
+// can't be fetched or has no description. The page is fetched through
+// FetchPage, so robots.txt and the crawl delay apply.
+func (a *Aggregator) metaSummary(ctx context.Context, pageURL string) string {
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	body, err := a.FetchPage(ctx, pageURL)
+	if err != nil {
+		return ""
+	}
+	return html.EscapeString(findMetaDescription(body))
+}
+
+// metaDescriptionKeys ranks the meta tag names and properties that
+// findMetaDescription accepts, best first.
+var metaDescriptionKeys = []string{"og:description", "description", "twitter:description"}
+
+// findMetaDescription returns the best description meta tag content from
+// an HTML document's head.
+func findMetaDescription(page []byte) string {
+	found := make(map[string]string)
+	z := html.NewTokenizer(bytes.NewReader(page))
+	for done := false; !done; {
+		switch z.Next() {
+		case html.ErrorToken:
+			done = true
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				done = true
+			case "meta":
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "name", "property":
+						key = strings.ToLower(strings.TrimSpace(string(v)))
+					case "content":
+						content = strings.Join(strings.Fields(string(v)), " ")
+					}
+				}
+				if _, ok := found[key]; !ok && content != "" {
+					found[key] = content
+				}
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				done = true
+			}
+		}
+	}
+	for _, key := range metaDescriptionKeys {
+		if s := found[key]; s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,326 @@
+package aggregator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDisallowedByRobots is returned by FetchPage when robots.txt disallows the URL.
+var ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+// maxPageBytes bounds how much of an article page FetchPage reads.
+const maxPageBytes = 2 << 20
+
+// hostState tracks per-host politeness state for the duration of a run.
+// Its lock is held only to read and update the state, never across a
+// request or a crawl delay.
+type hostState struct {
+	mu      sync.Mutex   // guards the fields below
+	robots  *robotsRules // Cached robots.txt rules
+	loading *robotsFetch // robots.txt fetch in flight, if any
+	next    time.Time    // Earliest start of the next page fetch
+}
+
+// robotsFetch is a robots.txt fetch that concurrent page fetches share.
+type robotsFetch struct {
+	done  chan struct{} // Closed once rules is set
+	rules *robotsRules
+}
+
+// hostState returns the politeness state for a host, creating it if needed.
+func (a *Aggregator) hostState(host string) *hostState {
+	a.hostsMu.Lock()
+	defer a.hostsMu.Unlock()
+	hs, ok := a.hosts[host]
+	if !ok {
+		hs = &hostState{}
+		a.hosts[host] = hs
+	}
+	return hs
+}
+
+// FetchPage fetches an article page (not a feed) politely: requests to the
+// same host start at least the larger of Config.CrawlDelay and the host's
+// robots.txt Crawl-delay apart, and when Config.RespectRobots is set, URLs
+// disallowed for our user-agent return ErrDisallowedByRobots.
+// robots.txt is fetched once per host and cached for the Aggregator's
+// lifetime, unless fetching it failed (see loadRobots).
+// At most 2 MiB of the body is read.
+func (a *Aggregator) FetchPage(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme: %s", rawURL)
+	}
+
+	hs := a.hostState(u.Host)
+	delay := a.config.CrawlDelay
+	if a.config.RespectRobots {
+		robots, err := a.hostRobots(ctx, hs, u)
+		if err != nil {
+			return nil, err
+		}
+		if !robots.allowed(u.EscapedPath()) {
+			return nil, fmt.Errorf("%w: %s", ErrDisallowedByRobots, rawURL)
+		}
+		if robots.crawlDelay > delay {
+			delay = robots.crawlDelay
+		}
+	}
+
+	// Reserve the host's next start time, then wait for it unlocked
+	hs.mu.Lock()
+	start := time.Now()
+	if hs.next.After(start) {
+		start = hs.next
+	}
+	hs.next = start.Add(delay)
+	hs.mu.Unlock()
+	if wait := time.Until(start); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", a.config.UserAgent)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+}
+
+// hostRobots returns the robots.txt rules of the host of u, fetching them
+// unless cached. Concurrent callers share one fetch, waiting for it
+// without holding the host's lock.
+func (a *Aggregator) hostRobots(ctx context.Context, hs *hostState, u *url.URL) (*robotsRules, error) {
+	hs.mu.Lock()
+	if hs.robots != nil {
+		defer hs.mu.Unlock()
+		return hs.robots, nil
+	}
+	if f := hs.loading; f != nil {
+		hs.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.rules, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	f := &robotsFetch{done: make(chan struct{})}
+	hs.loading = f
+	hs.mu.Unlock()
+
+	rules, cache := a.loadRobots(ctx, u)
+	hs.mu.Lock()
+	if cache {
+		hs.robots = rules
+	}
+	hs.loading = nil
+	hs.mu.Unlock()
+	f.rules = rules
+	close(f.done)
+	return rules, nil
+}
+
+// loadRobots fetches and parses robots.txt for the URL's host, reporting
+// whether the rules may be cached for the run.
+// Per RFC 9309, a 4xx response means everything is allowed, while an
+// unreachable host or 5xx response means everything is disallowed. Those
+// failures may be transient, such as a timeout, so they aren't cached and
+// the next page fetch from the host tries again.
+func (a *Aggregator) loadRobots(ctx context.Context, u *url.URL) (rules *robotsRules, cache bool) {
+	robotsURL := u.Scheme + "://" + u.Host + "/robots.txt"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return &robotsRules{disallowAll: true}, true
+	}
+	req.Header.Set("User-Agent", a.config.UserAgent)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return &robotsRules{disallowAll: true}, false
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return &robotsRules{disallowAll: true}, false
+	case resp.StatusCode >= 400:
+		return &robotsRules{}, true
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 500<<10))
+	if err != nil {
+		return &robotsRules{disallowAll: true}, false
+	}
+	return parseRobots(body, userAgentToken(a.config.UserAgent)), true
+}
+
+// userAgentToken returns the product token of a User-Agent string,
+// e.g., "Signal/1.0 (+https://...)" → "signal".
+func userAgentToken(ua string) string {
+	token := ua
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+	return strings.ToLower(token)
+}
+
+// robotsRules are the robots.txt rules that apply to our user-agent.
+type robotsRules struct {
+	disallowAll bool
+	rules       []robotsRule
+	crawlDelay  time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// parseRobots extracts the rules of the groups naming token, compared
+// case-insensitively with each whole User-agent value, falling back to the
+// "*" groups. As RFC 9309 requires, the rules of every matching group are
+// combined; the crawl delay is the longest any of them sets.
+func parseRobots(data []byte, token string) *robotsRules {
+	type group struct {
+		agents []string
+		rules  []robotsRule
+		delay  time.Duration
+	}
+	var groups []*group
+	var current *group
+	inAgents := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if !inAgents {
+				current = &group{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			continue
+		}
+		inAgents = false
+		if current == nil {
+			continue
+		}
+		switch key {
+		case "allow", "disallow":
+			// An empty Disallow allows everything, so it adds no rule
+			if value != "" {
+				current.rules = append(current.rules, robotsRule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+				current.delay = time.Duration(secs * float64(time.Second))
+			}
+		}
+	}
+
+	// An empty User-agent line names no crawler, and token is lowercase
+	var matched, wildcard []*group
+	for _, g := range groups {
+		if token != "" && slices.Contains(g.agents, token) {
+			matched = append(matched, g)
+		} else if slices.Contains(g.agents, "*") {
+			wildcard = append(wildcard, g)
+		}
+	}
+	if len(matched) == 0 {
+		matched = wildcard
+	}
+	r := &robotsRules{}
+	for _, g := range matched {
+		r.rules = append(r.rules, g.rules...)
+		r.crawlDelay = max(r.crawlDelay, g.delay)
+	}
+	return r
+}
+
+// allowed reports whether path may be fetched. The longest matching rule
+// wins, and Allow wins a tie, as specified by RFC 9309.
+func (r *robotsRules) allowed(path string) bool {
+	if r.disallowAll {
+		return false
+	}
+	if path == "" {
+		path = "/"
+	}
+	allow, best := true, -1
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		if n := len(rule.pattern); n > best || (n == best && rule.allow) {
+			allow, best = rule.allow, n
+		}
+	}
+	return allow
+}
+
+// robotsMatch matches a robots.txt path pattern supporting "*" wildcards
+// and a trailing "$" end anchor.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	if anchored && rest != "" {
+		// The last literal must end the path; retry with the final part anchored.
+		last := parts[len(parts)-1]
+		return len(parts) > 1 && strings.HasSuffix(path, last)
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRobotsIgnoresEmptyUserAgent(t *testing.T) {
+	rules := parseRobots([]byte("User-agent:\nDisallow: /\n"), "signal")
+	if !rules.allowed("/post") {
+		t.Error("an empty User-agent group applied to every crawler")
+	}
+}
+
+func TestParseRobotsGroups(t *testing.T) {
+	tests := []struct {
+		name      string
+		robots    string
+		allowed   []string
+		disallow  []string
+		wantDelay time.Duration
+	}{
+		{
+			name:     "substring agents don't match",
+			robots:   "User-agent: s\nDisallow: /s\n\nUser-agent: sig\nDisallow: /sig\n\nUser-agent: signalbot\nDisallow: /bot\n\nUser-agent: *\nDisallow: /all\n",
+			allowed:  []string{"/s", "/sig", "/bot"},
+			disallow: []string{"/all"},
+		},
+		{
+			name:     "whole token matches case-insensitively",
+			robots:   "User-agent: Signal\nDisallow: /private\n\nUser-agent: *\nDisallow: /\n",
+			allowed:  []string{"/post"},
+			disallow: []string{"/private"},
+		},
+		{
+			name:      "split groups for the token are merged",
+			robots:    "User-agent: signal\nDisallow: /a\nCrawl-delay: 2\n\nUser-agent: other\nDisallow: /\n\nUser-agent: signal\nDisallow: /b\nCrawl-delay: 5\n",
+			allowed:   []string{"/c"},
+			disallow:  []string{"/a", "/b"},
+			wantDelay: 5 * time.Second,
+		},
+		{
+			name:      "split wildcard groups are merged",
+			robots:    "User-agent: *\nDisallow: /a\n\nUser-agent: other\nDisallow: /\n\nUser-agent: *\nDisallow: /b\nCrawl-delay: 3\n",
+			allowed:   []string{"/c"},
+			disallow:  []string{"/a", "/b"},
+			wantDelay: 3 * time.Second,
+		},
+		{
+			name:     "a matching group replaces the wildcard groups",
+			robots:   "User-agent: *\nDisallow: /a\n\nUser-agent: signal\nDisallow: /b\n",
+			allowed:  []string{"/a"},
+			disallow: []string{"/b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := parseRobots([]byte(tt.robots), "signal")
+			for _, path := range tt.allowed {
+				if !rules.allowed(path) {
+					t.Errorf("%s disallowed, want allowed", path)
+				}
+			}
+			for _, path := range tt.disallow {
+				if rules.allowed(path) {
+					t.Errorf("%s allowed, want disallowed", path)
+				}
+			}
+			if rules.crawlDelay != tt.wantDelay {
+				t.Errorf("crawlDelay = %v, want %v", rules.crawlDelay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRobotsFailureIsNotCached(t *testing.T) {
+	var robotsHits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			if robotsHits.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+			return
+		}
+		w.Write([]byte("<html></html>"))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.CrawlDelay = 0
+	a := New(cfg)
+	ctx := context.Background()
+
+	if _, err := a.FetchPage(ctx, srv.URL+"/post"); !errors.Is(err, ErrDisallowedByRobots) {
+		t.Fatalf("with robots.txt unavailable: err = %v, want ErrDisallowedByRobots", err)
+	}
+	if _, err := a.FetchPage(ctx, srv.URL+"/post"); err != nil {
+		t.Fatalf("after robots.txt recovered: %v", err)
+	}
+	if _, err := a.FetchPage(ctx, srv.URL+"/private"); !errors.Is(err, ErrDisallowedByRobots) {
+		t.Fatalf("disallowed path: err = %v, want ErrDisallowedByRobots", err)
+	}
+	if n := robotsHits.Load(); n != 2 {
+		t.Errorf("robots.txt fetched %d times, want 2 (retried once, then cached)", n)
+	}
+}
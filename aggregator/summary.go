@@ -0,0 +1,82 @@
+package aggregator
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/grokify/signal/internal/fileutil"
+)
+
+// RunSummary is machine-readable metadata about an aggregation run,
+// intended for monitoring and alerting.
+type RunSummary struct {
+	StartedAt   time.Time     `json:"startedAt"`
+	FinishedAt  time.Time     `json:"finishedAt"`
+	DurationMS  int64         `json:"durationMs"`
+	FeedsTotal  int           `json:"feedsTotal"`
+	FeedsOK     int           `json:"feedsOK"`
+	FeedsFailed int           `json:"feedsFailed"`
+	Failures    []FeedFailure `json:"failures"`
+	Entries     int           `json:"entries"`
+
+	// DuplicatesRemoved counts fetched entries collapsed by deduplication,
+	// and MergeDuplicatesRemoved those collapsed when merging with the
+	// entries already in the monthly files. Callers that deduplicate
+	// set them.
+	DuplicatesRemoved      int `json:"duplicatesRemoved"`
+	MergeDuplicatesRemoved int `json:"mergeDuplicatesRemoved"`
+}
+
+// FeedFailure records a feed that failed to fetch.
+type FeedFailure struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+	Error string `json:"error"`
+}
+
+// NewRunSummary summarizes the fetch results of a run that started at
+// startedAt and produced the given number of output entries.
+// FinishedAt is set to the current time.
+func NewRunSummary(startedAt time.Time, results []FetchResult, entries int) *RunSummary {
+	finishedAt := time.Now().UTC()
+	s := &RunSummary{
+		StartedAt:  startedAt.UTC(),
+		FinishedAt: finishedAt,
+		DurationMS: finishedAt.Sub(startedAt).Milliseconds(),
+		FeedsTotal: len(results),
+		Failures:   []FeedFailure{},
+		Entries:    entries,
+	}
+	for _, r := range results {
+		if r.Error == nil {
+			s.FeedsOK++
+			continue
+		}
+		s.FeedsFailed++
+		s.Failures = append(s.Failures, FeedFailure{
+			URL:   r.Outline.XMLURL,
+			Title: r.Outline.Title,
+			Error: r.Error.Error(),
+		})
+	}
+	sort.Slice(s.Failures, func(i, j int) bool {
+		return s.Failures[i].URL < s.Failures[j].URL
+	})
+	return s
+}
+
+// WriteFile writes the summary to a JSON file.
+func (s *RunSummary) WriteFile(filename string) error {
+	return s.WriteFileMode(filename, 0644)
+}
+
+// WriteFileMode writes the summary to a JSON file with permissions perm.
+func (s *RunSummary) WriteFileMode(filename string, perm os.FileMode) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(filename, data, perm)
+}
@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/grokify/signal/entry"
+)
+
+// TestSummaryTruncation covers the summary fallback's truncation, where n
+// includes the "..." marker.
+func TestSummaryTruncation(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		n    int
+		want string
+	}{
+		{"French under the limit", "Un café crème", 13, "Un café crème"},
+		{"French mid-word backs up to a space", "Le café est très bon", 15, "Le café est..."},
+		{"French cut at the end of a word", "Déjà vu à Noël", 12, "Déjà vu à..."},
+		{"French accents count as one rune", "Élève émérite", 8, "Élève..."},
+		{"Japanese without spaces", "日本語のテキストはスペースがありません", 8, "日本語のテ..."},
+		{"Japanese ideographic space", "東京都の今日は　いい天気", 11, "東京都の今日は..."},
+		{
+			"tag that doesn't fit is dropped whole",
+			`<p>Le <a href="https://example.com/café">café</a> est bon</p>`, 20,
+			"<p>Le...</p>",
+		},
+		{
+			"Japanese inside an element is closed",
+			"<p><em>日本語のテキスト</em>はスペースがありません</p>", 14,
+			"<p><em>日本語の...</em></p>",
+		},
+		{
+			"accented text after markup",
+			"<p>Un <strong>café</strong> crème brûlée</p>", 40,
+			"<p>Un <strong>café</strong> crème...</p>",
+		},
+		{"character reference not split", "<p>Caf&eacute; cr&egrave;me</p>", 13, "<p>Caf...</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := entry.TruncateHTMLWithMarker(tt.in, tt.n, "...")
+			if got != tt.want {
+				t.Errorf("TruncateHTMLWithMarker(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateHTMLWithMarker(%q, %d) = %q, not valid UTF-8", tt.in, tt.n, got)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package api
 
 import (
-	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,7 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/grokify/signal/atom"
 	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/jsonfeed"
+	"github.com/grokify/signal/rss"
 )
 
 // SignalVersion is the version of Signal.
@@ -26,7 +29,27 @@ func SignalGenerator() Generator {
 
 // Generate creates the complete API structure from a feed.
 func Generate(feed *entry.Feed, sources []SourceInfo, cfg Config) error {
-	now := time.Now().UTC()
+	_, err := GenerateWithReport(feed, sources, cfg)
+	return err
+}
+
+// GenerateWithReport creates the complete API structure from a feed and
+// reports how many files were written or skipped as unchanged.
+func GenerateWithReport(feed *entry.Feed, sources []SourceInfo, cfg Config) (*Report, error) {
+	now := cfg.GeneratedAt.UTC()
+	if cfg.GeneratedAt.IsZero() {
+		now = time.Now().UTC()
+	}
+	if cfg.FileMode == 0 {
+		cfg.FileMode = DefaultFileMode
+	}
+	if cfg.DirMode == 0 {
+		cfg.DirMode = DefaultDirMode
+	}
+	if cfg.WriteConcurrency <= 0 {
+		cfg.WriteConcurrency = DefaultWriteConcurrency
+	}
+	w := &fileWriter{skipUnchanged: cfg.SkipUnchanged, omitGenerated: cfg.OmitGenerated, fileMode: cfg.FileMode, onWrite: cfg.onWrite}
 	baseDir := filepath.Join(cfg.OutputDir, cfg.Version)
 
 	// Create directory structure
@@ -39,54 +62,65 @@ func Generate(feed *entry.Feed, sources []SourceInfo, cfg Config) error {
 		filepath.Join(baseDir, "by-tag"),
 	}
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		if err := os.MkdirAll(dir, cfg.DirMode); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
 
+	// Tagged entries count under their ancestor tags too
+	tagged := feed
+	if len(cfg.TagParents) > 0 {
+		tagged = expandTagHierarchy(feed, cfg.TagParents)
+	}
+
 	// Analyze entries
-	analysis := analyzeEntries(feed.Entries, sources)
+	analysis := analyzeEntries(tagged.Entries, sources, now)
 
 	// Generate meta files
-	if err := generateMetaFiles(baseDir, cfg, analysis, now); err != nil {
-		return fmt.Errorf("failed to generate meta files: %w", err)
+	if err := generateMetaFiles(w, baseDir, cfg, analysis, now); err != nil {
+		return nil, fmt.Errorf("failed to generate meta files: %w", err)
 	}
 
 	// Generate feeds
-	if err := generateFeeds(baseDir, feed, cfg, now); err != nil {
-		return fmt.Errorf("failed to generate feeds: %w", err)
+	if err := generateFeeds(w, baseDir, feed, cfg, now); err != nil {
+		return nil, fmt.Errorf("failed to generate feeds: %w", err)
 	}
 
 	// Generate by-month files
-	if err := generateByMonth(baseDir, feed, now); err != nil {
-		return fmt.Errorf("failed to generate by-month files: %w", err)
+	if err := generateByMonth(w, baseDir, feed, cfg, now); err != nil {
+		return nil, fmt.Errorf("failed to generate by-month files: %w", err)
 	}
 
 	// Generate by-source files
-	if err := generateBySource(baseDir, feed, analysis, now); err != nil {
-		return fmt.Errorf("failed to generate by-source files: %w", err)
+	if err := generateBySource(w, baseDir, feed, analysis, cfg, now); err != nil {
+		return nil, fmt.Errorf("failed to generate by-source files: %w", err)
 	}
 
 	// Generate by-tag files
-	if err := generateByTag(baseDir, feed, analysis, now); err != nil {
-		return fmt.Errorf("failed to generate by-tag files: %w", err)
+	if err := generateByTag(w, baseDir, tagged, analysis, cfg, now); err != nil {
+		return nil, fmt.Errorf("failed to generate by-tag files: %w", err)
 	}
 
 	// Generate schema.json
 	if cfg.GenerateSchema {
-		if err := generateSchema(baseDir); err != nil {
-			return fmt.Errorf("failed to generate schema: %w", err)
+		if err := generateSchema(w, baseDir); err != nil {
+			return nil, fmt.Errorf("failed to generate schema: %w", err)
 		}
 	}
 
 	// Generate AGENTS.md
 	if cfg.GenerateAgentsMD {
-		if err := generateAgentsMD(baseDir, cfg, analysis, now); err != nil {
-			return fmt.Errorf("failed to generate AGENTS.md: %w", err)
+		if err := generateAgentsMD(w, baseDir, cfg, analysis, now); err != nil {
+			return nil, fmt.Errorf("failed to generate AGENTS.md: %w", err)
 		}
 	}
 
-	return nil
+	// Generate meta/manifest.json last, so it lists every other file
+	if err := generateManifest(w, baseDir, cfg, now); err != nil {
+		return nil, fmt.Errorf("failed to generate manifest: %w", err)
+	}
+
+	return w.report(), nil
 }
 
 // SourceInfo contains information about a feed source from OPML.
@@ -98,6 +132,40 @@ type SourceInfo struct {
 	Categories  []string
 }
 
+// sortedKeys returns a map's keys in sorted order, so files are generated
+// in a stable order and ties in count-sorted indexes break by key.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SourcesFromEntries reconstructs source metadata from entries' Feed
+// fields, for when the OPML feed list isn't available. Only the title,
+// home page URL, and description are known; sources are sorted by title.
+func SourcesFromEntries(entries []entry.Entry) []SourceInfo {
+	seen := make(map[string]bool)
+	var sources []SourceInfo
+	for _, e := range entries {
+		if e.Feed.Title == "" || seen[e.Feed.Title] {
+			continue
+		}
+		seen[e.Feed.Title] = true
+		sources = append(sources, SourceInfo{
+			Title:       e.Feed.Title,
+			Description: e.Feed.Description,
+			HTMLURL:     e.Feed.URL,
+		})
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].Title < sources[j].Title
+	})
+	return sources
+}
+
 // Analysis contains analyzed data from entries.
 type Analysis struct {
 	TotalEntries    int
@@ -108,6 +176,7 @@ type Analysis struct {
 	EntriesByMonth  map[string]int
 	EntriesBySource map[string]*SourceAnalysis
 	EntriesByTag    map[string]int
+	TagTitles       map[string]string // lowercase tag -> canonical display casing
 	SourceInfo      map[string]SourceInfo
 }
 
@@ -118,13 +187,29 @@ type SourceAnalysis struct {
 	Count       int
 	OldestEntry time.Time
 	NewestEntry time.Time
+	// DaysSinceNewest is the number of whole days between NewestEntry and
+	// the generation time
+	DaysSinceNewest int
+	// Description and HomeURL come from the fetched feed, as a fallback
+	// for sources the OPML doesn't describe
+	Description string
+	HomeURL     string
 }
 
-func analyzeEntries(entries []entry.Entry, sources []SourceInfo) *Analysis {
+// analyzeEntries builds on entry.Feed.Stats, adding source slugs, their
+// age as of now, and the OPML source metadata.
+func analyzeEntries(entries []entry.Entry, sources []SourceInfo, now time.Time) *Analysis {
+	stats := (&entry.Feed{Entries: entries}).Stats()
 	a := &Analysis{
-		EntriesByMonth:  make(map[string]int),
-		EntriesBySource: make(map[string]*SourceAnalysis),
-		EntriesByTag:    make(map[string]int),
+		TotalEntries:    stats.TotalEntries,
+		TotalSources:    stats.TotalSources,
+		TotalTags:       stats.TotalTags,
+		OldestEntry:     stats.OldestEntry,
+		NewestEntry:     stats.NewestEntry,
+		EntriesByMonth:  stats.EntriesByMonth,
+		EntriesBySource: make(map[string]*SourceAnalysis, len(stats.EntriesBySource)),
+		EntriesByTag:    stats.EntriesByTag,
+		TagTitles:       stats.TagTitles,
 		SourceInfo:      make(map[string]SourceInfo),
 	}
 
@@ -133,56 +218,36 @@ func analyzeEntries(entries []entry.Entry, sources []SourceInfo) *Analysis {
 		a.SourceInfo[s.Title] = s
 	}
 
-	for _, e := range entries {
-		a.TotalEntries++
-
-		// Date range
-		if a.OldestEntry.IsZero() || e.Date.Before(a.OldestEntry) {
-			a.OldestEntry = e.Date
+	for title, ss := range stats.EntriesBySource {
+		sa := &SourceAnalysis{
+			Title:       ss.Title,
+			Slug:        Slugify(ss.Title),
+			Count:       ss.Count,
+			OldestEntry: ss.OldestEntry,
+			NewestEntry: ss.NewestEntry,
 		}
-		if a.NewestEntry.IsZero() || e.Date.After(a.NewestEntry) {
-			a.NewestEntry = e.Date
+		if !ss.NewestEntry.IsZero() && now.After(ss.NewestEntry) {
+			sa.DaysSinceNewest = int(now.Sub(ss.NewestEntry).Hours() / 24)
 		}
-
-		// By month
-		month := e.Date.Format("2006-01")
-		a.EntriesByMonth[month]++
-
-		// By source
-		sourceTitle := e.Feed.Title
-		if sourceTitle == "" {
-			sourceTitle = "Unknown"
-		}
-		if a.EntriesBySource[sourceTitle] == nil {
-			a.EntriesBySource[sourceTitle] = &SourceAnalysis{
-				Title:       sourceTitle,
-				Slug:        Slugify(sourceTitle),
-				OldestEntry: e.Date,
-				NewestEntry: e.Date,
-			}
-		}
-		sa := a.EntriesBySource[sourceTitle]
-		sa.Count++
-		if e.Date.Before(sa.OldestEntry) {
-			sa.OldestEntry = e.Date
+		a.EntriesBySource[title] = sa
+	}
+	for _, e := range entries {
+		sa, ok := a.EntriesBySource[e.Feed.Title]
+		if !ok {
+			continue
 		}
-		if e.Date.After(sa.NewestEntry) {
-			sa.NewestEntry = e.Date
+		if sa.Description == "" {
+			sa.Description = e.Feed.Description
 		}
-
-		// By tag
-		for _, tag := range e.Tags {
-			a.EntriesByTag[strings.ToLower(tag)]++
+		if sa.HomeURL == "" {
+			sa.HomeURL = e.Feed.URL
 		}
 	}
 
-	a.TotalSources = len(a.EntriesBySource)
-	a.TotalTags = len(a.EntriesByTag)
-
 	return a
 }
 
-func generateMetaFiles(baseDir string, cfg Config, analysis *Analysis, now time.Time) error {
+func generateMetaFiles(w *fileWriter, baseDir string, cfg Config, analysis *Analysis, now time.Time) error {
 	metaDir := filepath.Join(baseDir, "meta")
 
 	// about.json
@@ -197,17 +262,28 @@ func generateMetaFiles(baseDir string, cfg Config, analysis *Analysis, now time.
 	}
 	if cfg.OwnerName != "" {
 		about.Owner = &Owner{
-			Name: cfg.OwnerName,
-			URL:  cfg.OwnerURL,
+			Name:   cfg.OwnerName,
+			URL:    cfg.OwnerURL,
+			Avatar: cfg.OwnerAvatar,
 		}
 	}
-	if err := writeJSON(filepath.Join(metaDir, "about.json"), about); err != nil {
+	aboutPath := filepath.Join(metaDir, "about.json")
+	if cfg.PreserveMeta {
+		merged, err := mergeExistingJSON(aboutPath, about)
+		if err != nil {
+			return err
+		}
+		if err := w.writeJSON(aboutPath, merged); err != nil {
+			return err
+		}
+	} else if err := w.writeJSON(aboutPath, about); err != nil {
 		return err
 	}
 
 	// sources.json
 	var sourceEntries []SourceEntry
-	for title, sa := range analysis.EntriesBySource {
+	for _, title := range sortedKeys(analysis.EntriesBySource) {
+		sa := analysis.EntriesBySource[title]
 		se := SourceEntry{
 			Slug:        sa.Slug,
 			Title:       title,
@@ -222,9 +298,15 @@ func generateMetaFiles(baseDir string, cfg Config, analysis *Analysis, now time.
 			se.FeedURL = info.FeedURL
 			se.Categories = info.Categories
 		}
+		if se.Description == "" {
+			se.Description = sa.Description
+		}
+		if se.HTMLURL == "" {
+			se.HTMLURL = sa.HomeURL
+		}
 		sourceEntries = append(sourceEntries, se)
 	}
-	sort.Slice(sourceEntries, func(i, j int) bool {
+	sort.SliceStable(sourceEntries, func(i, j int) bool {
 		return sourceEntries[i].EntryCount > sourceEntries[j].EntryCount
 	})
 	sourcesMeta := SourcesMeta{
@@ -232,46 +314,74 @@ func generateMetaFiles(baseDir string, cfg Config, analysis *Analysis, now time.
 		Count:     len(sourceEntries),
 		Sources:   sourceEntries,
 	}
-	if err := writeJSON(filepath.Join(metaDir, "sources.json"), sourcesMeta); err != nil {
+	if err := w.writeJSON(filepath.Join(metaDir, "sources.json"), sourcesMeta); err != nil {
 		return err
 	}
 
 	// stats.json
 	var monthCounts []MonthCount
-	for month, count := range analysis.EntriesByMonth {
+	for _, month := range sortedKeys(analysis.EntriesByMonth) {
+		count := analysis.EntriesByMonth[month]
 		monthCounts = append(monthCounts, MonthCount{Month: month, Count: count})
 	}
-	sort.Slice(monthCounts, func(i, j int) bool {
+	sort.SliceStable(monthCounts, func(i, j int) bool {
 		return monthCounts[i].Month > monthCounts[j].Month
 	})
 
 	var sourceCounts []SourceCount
-	for title, sa := range analysis.EntriesBySource {
+	for _, title := range sortedKeys(analysis.EntriesBySource) {
+		sa := analysis.EntriesBySource[title]
 		sourceCounts = append(sourceCounts, SourceCount{
 			Slug:  sa.Slug,
 			Title: title,
 			Count: sa.Count,
 		})
 	}
-	sort.Slice(sourceCounts, func(i, j int) bool {
+	sort.SliceStable(sourceCounts, func(i, j int) bool {
 		return sourceCounts[i].Count > sourceCounts[j].Count
 	})
 
 	var tagCounts []TagCount
-	for tag, count := range analysis.EntriesByTag {
+	for _, tag := range sortedKeys(analysis.EntriesByTag) {
+		count := analysis.EntriesByTag[tag]
 		tagCounts = append(tagCounts, TagCount{
-			Tag:   tag,
+			Tag:   analysis.TagTitles[tag],
 			Slug:  Slugify(tag),
 			Count: count,
 		})
 	}
-	sort.Slice(tagCounts, func(i, j int) bool {
+	sort.SliceStable(tagCounts, func(i, j int) bool {
 		return tagCounts[i].Count > tagCounts[j].Count
 	})
-	if len(tagCounts) > 20 {
-		tagCounts = tagCounts[:20]
+	topTags := cfg.TopTagsLimit
+	if topTags <= 0 {
+		topTags = DefaultTopTagsLimit
+	}
+	if len(tagCounts) > topTags {
+		tagCounts = tagCounts[:topTags]
 	}
 
+	staleDays := cfg.StaleDays
+	if staleDays <= 0 {
+		staleDays = DefaultStaleDays
+	}
+	staleSources := []StaleSource{}
+	for _, title := range sortedKeys(analysis.EntriesBySource) {
+		sa := analysis.EntriesBySource[title]
+		if sa.NewestEntry.IsZero() || sa.DaysSinceNewest < staleDays {
+			continue
+		}
+		staleSources = append(staleSources, StaleSource{
+			Slug:            sa.Slug,
+			Title:           title,
+			LastSeen:        sa.NewestEntry,
+			DaysSinceNewest: sa.DaysSinceNewest,
+		})
+	}
+	sort.SliceStable(staleSources, func(i, j int) bool {
+		return staleSources[i].DaysSinceNewest > staleSources[j].DaysSinceNewest
+	})
+
 	stats := StatsMeta{
 		Generated:    now,
 		TotalEntries: analysis.TotalEntries,
@@ -284,18 +394,56 @@ func generateMetaFiles(baseDir string, cfg Config, analysis *Analysis, now time.
 		EntriesByMonth:  monthCounts,
 		EntriesBySource: sourceCounts,
 		TopTags:         tagCounts,
+		StaleSources:    staleSources,
 	}
-	return writeJSON(filepath.Join(metaDir, "stats.json"), stats)
+	return w.writeJSON(filepath.Join(metaDir, "stats.json"), stats)
 }
 
-func generateFeeds(baseDir string, feed *entry.Feed, cfg Config, now time.Time) error {
+func generateFeeds(w *fileWriter, baseDir string, feed *entry.Feed, cfg Config, now time.Time) error {
 	feedsDir := filepath.Join(baseDir, "feeds")
 
 	// latest.json - use existing ToJSONFeed conversion
 	latestFeed := filterLatestMonths(feed, cfg.LatestMonths)
-	jf := latestFeed.ToJSONFeed()
+	if cfg.MaxTotal > 0 && len(latestFeed.Entries) > cfg.MaxTotal {
+		capped := *latestFeed
+		capped.Entries = append([]entry.Entry(nil), latestFeed.Entries...)
+		capped.Truncate(cfg.MaxTotal)
+		latestFeed = &capped
+	}
+	jf := planetFeed(latestFeed, cfg, "feeds/latest.json")
+	if err := w.writeFeed(filepath.Join(feedsDir, "latest.json"), jf); err != nil {
+		return err
+	}
+
+	// recent.json - a small file for frequent pollers
+	if cfg.RecentDays > 0 {
+		recentFeed := *feed
+		recentFeed.Entries = feed.FilterByDateRange(now.AddDate(0, 0, -cfg.RecentDays), time.Time{})
+		jf := planetFeed(&recentFeed, cfg, "feeds/recent.json")
+		if err := w.writeFeed(filepath.Join(feedsDir, "recent.json"), jf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planetFeed converts f to a planet-level JSON Feed published at the API
+// path rel, with the planet's title, language, icons, and owner.
+func planetFeed(f *entry.Feed, cfg Config, rel string) *jsonfeed.Feed {
+	jf := f.ToJSONFeed()
 	jf.Title = cfg.PlanetName
-	return jf.WriteFile(filepath.Join(feedsDir, "latest.json"))
+	jf.Language = cfg.DefaultLanguage
+	jf.FeedURL = absoluteURL(cfg, apiPath(cfg, rel))
+	jf.Icon = cfg.IconURL
+	jf.Favicon = cfg.FaviconURL
+	if cfg.OwnerName != "" {
+		jf.Authors = []jsonfeed.Author{{
+			Name:   cfg.OwnerName,
+			URL:    cfg.OwnerURL,
+			Avatar: cfg.OwnerAvatar,
+		}}
+	}
+	return jf
 }
 
 func filterLatestMonths(feed *entry.Feed, months int) *entry.Feed {
@@ -322,7 +470,7 @@ func filterLatestMonths(feed *entry.Feed, months int) *entry.Feed {
 	return filtered
 }
 
-func generateByMonth(baseDir string, feed *entry.Feed, now time.Time) error {
+func generateByMonth(w *fileWriter, baseDir string, feed *entry.Feed, cfg Config, now time.Time) error {
 	byMonthDir := filepath.Join(baseDir, "by-month")
 
 	// Group entries by month
@@ -334,11 +482,16 @@ func generateByMonth(baseDir string, feed *entry.Feed, now time.Time) error {
 
 	// Generate index
 	var monthRefs []MonthRef
-	for month, entries := range byMonth {
+	var jobs []func() error
+	files := make(map[string]string) // API path -> filename, for index hashes
+	for _, month := range sortedKeys(byMonth) {
+		entries := byMonth[month]
+		rel := monthFilePath(cfg, month)
+		path := apiPath(cfg, "by-month/"+rel)
 		monthRefs = append(monthRefs, MonthRef{
 			Month: month,
 			Count: len(entries),
-			Path:  fmt.Sprintf("/v1/by-month/%s.json", month),
+			Path:  path,
 		})
 
 		// Generate month file
@@ -349,24 +502,140 @@ func generateByMonth(baseDir string, feed *entry.Feed, now time.Time) error {
 		}
 		jf := monthFeed.ToJSONFeed()
 		jf.SignalPeriod = month
-		if err := jf.WriteFile(filepath.Join(byMonthDir, month+".json")); err != nil {
+		jf.Language = cfg.DefaultLanguage
+		jf.FeedURL = absoluteURL(cfg, path)
+		filename := filepath.Join(byMonthDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(filename), cfg.DirMode); err != nil {
 			return err
 		}
+		jobs = append(jobs, func() error { return w.writeFeed(filename, jf) })
+		files[path] = filename
+	}
+	if err := runJobs(cfg.WriteConcurrency, jobs); err != nil {
+		return err
 	}
 
-	sort.Slice(monthRefs, func(i, j int) bool {
+	sort.SliceStable(monthRefs, func(i, j int) bool {
 		return monthRefs[i].Month > monthRefs[j].Month
 	})
 
+	if cfg.NestByYear {
+		return generateYearIndexes(w, byMonthDir, monthRefs, files, cfg, now)
+	}
+
 	index := MonthIndex{
 		Generated: now,
 		Count:     len(monthRefs),
+		Hash:      w.indexHash(files),
 		Months:    monthRefs,
 	}
-	return writeJSON(filepath.Join(byMonthDir, "index.json"), index)
+	return w.writeJSON(filepath.Join(byMonthDir, "index.json"), index)
+}
+
+// monthIndexHint describes how to find monthly archives for AGENTS.md.
+func monthIndexHint(cfg Config) string {
+	if cfg.NestByYear {
+		return "read `/v1/by-month/index.json` (newest year first), then each year's index at its `path` (newest month first),"
+	}
+	return "read `/v1/by-month/index.json` (newest month first)"
+}
+
+// recentRow returns the AGENTS.md quick start row for feeds/recent.json,
+// or "" when it isn't generated.
+func recentRow(cfg Config) string {
+	if cfg.RecentDays <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("| Entries from the last %d days (for polling) | `/v1/feeds/recent.json` |\n", cfg.RecentDays)
+}
+
+// pollingSection returns the AGENTS.md advice for polling feeds/recent.json,
+// or "" when it isn't generated.
+func pollingSection(cfg Config) string {
+	if cfg.RecentDays <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+## Polling for Updates
+
+`+"`/v1/feeds/recent.json`"+` holds only the entries of the last %d days, so it stays small. To keep up with new posts:
+
+1. Poll `+"`GET /v1/feeds/recent.json`"+` no more often than you need, and add items whose `+"`id`"+` you haven't seen
+2. If you haven't polled for more than %d days, read `+"`/v1/feeds/latest.json`"+` (or the monthly archives) once to catch up
+3. Use HTTP conditional requests (`+"`If-None-Match`"+`/`+"`If-Modified-Since`"+`) where the host supports them
+`, cfg.RecentDays, cfg.RecentDays)
+}
+
+// fullHistorySteps returns the AGENTS.md steps for reading every monthly archive.
+func fullHistorySteps(cfg Config) string {
+	if cfg.NestByYear {
+		return "1. `GET /v1/by-month/index.json`\n" +
+			"2. `GET` each `years[].path` in order\n" +
+			"3. `GET` each `months[].path` in order until you have enough entries"
+	}
+	return "1. `GET /v1/by-month/index.json`\n" +
+		"2. `GET` each `months[].path` in order until you have enough entries"
+}
+
+// monthFilePath returns a month's file path relative to by-month/:
+// "2026-02.json", or "2026/02.json" when Config.NestByYear is set.
+func monthFilePath(cfg Config, month string) string {
+	if cfg.NestByYear {
+		return strings.Replace(month, "-", "/", 1) + ".json"
+	}
+	return month + ".json"
+}
+
+// generateYearIndexes writes by-month/index.json listing years and a
+// by-month/{YYYY}/index.json per year listing its months. monthRefs must
+// be sorted newest first; files maps their paths to the written files.
+func generateYearIndexes(w *fileWriter, byMonthDir string, monthRefs []MonthRef, files map[string]string, cfg Config, now time.Time) error {
+	var yearRefs []YearRef
+	byYear := make(map[string][]MonthRef)
+	for _, ref := range monthRefs {
+		year := ref.Month[:4]
+		if _, ok := byYear[year]; !ok {
+			yearRefs = append(yearRefs, YearRef{
+				Year: year,
+				Path: apiPath(cfg, "by-month/"+year+"/index.json"),
+			})
+		}
+		byYear[year] = append(byYear[year], ref)
+	}
+
+	yearFiles := make(map[string]string) // year index path -> filename
+	for i, ref := range yearRefs {
+		months := byYear[ref.Year]
+		monthFiles := make(map[string]string)
+		for _, m := range months {
+			yearRefs[i].Count += m.Count
+			monthFiles[m.Path] = files[m.Path]
+		}
+		yearRefs[i].Months = len(months)
+
+		index := MonthIndex{
+			Generated: now,
+			Count:     len(months),
+			Hash:      w.indexHash(monthFiles),
+			Months:    months,
+		}
+		filename := filepath.Join(byMonthDir, ref.Year, "index.json")
+		if err := w.writeJSON(filename, index); err != nil {
+			return err
+		}
+		yearFiles[ref.Path] = filename
+	}
+
+	index := YearIndex{
+		Generated: now,
+		Count:     len(yearRefs),
+		Hash:      w.indexHash(yearFiles),
+		Years:     yearRefs,
+	}
+	return w.writeJSON(filepath.Join(byMonthDir, "index.json"), index)
 }
 
-func generateBySource(baseDir string, feed *entry.Feed, analysis *Analysis, now time.Time) error {
+func generateBySource(w *fileWriter, baseDir string, feed *entry.Feed, analysis *Analysis, cfg Config, now time.Time) error {
 	bySourceDir := filepath.Join(baseDir, "by-source")
 
 	// Group entries by source
@@ -374,20 +643,27 @@ func generateBySource(baseDir string, feed *entry.Feed, analysis *Analysis, now
 	for _, e := range feed.Entries {
 		title := e.Feed.Title
 		if title == "" {
-			title = "Unknown"
+			title = entry.UnknownSource
 		}
 		bySource[title] = append(bySource[title], e)
 	}
 
 	// Generate index
 	var sourceRefs []SourceRef
-	for title, entries := range bySource {
+	var jobs []func() error
+	files := make(map[string]string) // API path -> filename, for the index hash
+	for _, title := range sortedKeys(bySource) {
+		entries := bySource[title]
 		slug := Slugify(title)
+		path := apiPath(cfg, "by-source/"+slug+".json")
+		oldest, latest := entryDateRange(entries)
 		sourceRefs = append(sourceRefs, SourceRef{
-			Slug:  slug,
-			Title: title,
-			Count: len(entries),
-			Path:  fmt.Sprintf("/v1/by-source/%s.json", slug),
+			Slug:        slug,
+			Title:       title,
+			Count:       len(entries),
+			LatestEntry: latest,
+			OldestEntry: oldest,
+			Path:        path,
 		})
 
 		// Generate source file
@@ -397,50 +673,184 @@ func generateBySource(baseDir string, feed *entry.Feed, analysis *Analysis, now
 			Entries:   entries,
 		}
 		jf := sourceFeed.ToJSONFeed()
-		if err := jf.WriteFile(filepath.Join(bySourceDir, slug+".json")); err != nil {
-			return err
+		jf.FeedURL = absoluteURL(cfg, path)
+		jf.Language = cfg.DefaultLanguage
+		if lang, unanimous := entry.DominantLanguage(entries); unanimous {
+			jf.Language = lang
+		}
+		if author := commonAuthor(entries); author != "" {
+			jf.Authors = []jsonfeed.Author{{Name: author}}
+		}
+		for _, e := range entries {
+			if jf.Icon == "" {
+				jf.Icon = e.Feed.IconURL
+			}
+			if jf.HomePageURL == "" {
+				jf.HomePageURL = e.Feed.URL
+			}
+		}
+		if info, ok := sourceInfoFor(analysis, title, entries); ok {
+			jf.Description = info.Description
+			jf.SignalSourceFeedURL = info.FeedURL
+			if info.HTMLURL != "" {
+				jf.HomePageURL = info.HTMLURL
+			}
+		}
+		if sa, ok := analysis.EntriesBySource[title]; ok && jf.Description == "" {
+			jf.Description = sa.Description
+		}
+		filename := filepath.Join(bySourceDir, slug+".json")
+		jobs = append(jobs, func() error { return w.writeFeed(filename, jf) })
+		files[path] = filename
+
+		if len(cfg.GenerateSourceFeeds) > 0 {
+			// The source's home page is the feeds' alternate link
+			linked := *sourceFeed
+			linked.HomeURL = jf.HomePageURL
+			ref := &sourceRefs[len(sourceRefs)-1]
+			opts := atom.Options{
+				AuthorName: commonAuthor(linked.Entries),
+				IconURL:    jf.Icon,
+			}
+			if opts.AuthorName == "" {
+				// No single author; the planet owner publishes the feed
+				opts.AuthorName, opts.AuthorEmail, opts.AuthorURI = cfg.OwnerName, cfg.OwnerEmail, cfg.OwnerURL
+			}
+			feedJobs, err := generateFeedFiles(w, bySourceDir, slug, &linked, cfg.GenerateSourceFeeds, opts, &ref.AtomPath, &ref.RSSPath, cfg)
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, feedJobs...)
 		}
 	}
+	if err := runJobs(cfg.WriteConcurrency, jobs); err != nil {
+		return err
+	}
 
-	sort.Slice(sourceRefs, func(i, j int) bool {
+	sort.SliceStable(sourceRefs, func(i, j int) bool {
 		return sourceRefs[i].Count > sourceRefs[j].Count
 	})
 
 	index := SourceIndex{
 		Generated: now,
 		Count:     len(sourceRefs),
+		Hash:      w.indexHash(files),
 		Sources:   sourceRefs,
 	}
-	return writeJSON(filepath.Join(bySourceDir, "index.json"), index)
+	return w.writeJSON(filepath.Join(bySourceDir, "index.json"), index)
+}
+
+// sourceInfoFor returns the OPML-derived info for a source, matched by
+// title or, since a feed's own title may differ from its OPML title, by
+// home page URL.
+func sourceInfoFor(analysis *Analysis, title string, entries []entry.Entry) (SourceInfo, bool) {
+	if info, ok := analysis.SourceInfo[title]; ok {
+		return info, true
+	}
+	homeURL := ""
+	for _, e := range entries {
+		if e.Feed.URL != "" {
+			homeURL = e.Feed.URL
+			break
+		}
+	}
+	if homeURL == "" {
+		return SourceInfo{}, false
+	}
+	for _, key := range sortedKeys(analysis.SourceInfo) {
+		if info := analysis.SourceInfo[key]; info.HTMLURL == homeURL {
+			return info, true
+		}
+	}
+	return SourceInfo{}, false
+}
+
+// entryDateRange returns the oldest and newest entry dates, ignoring
+// entries without a date.
+func entryDateRange(entries []entry.Entry) (oldest, newest time.Time) {
+	for _, e := range entries {
+		if e.Date.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || e.Date.Before(oldest) {
+			oldest = e.Date
+		}
+		if e.Date.After(newest) {
+			newest = e.Date
+		}
+	}
+	return oldest, newest
 }
 
-func generateByTag(baseDir string, feed *entry.Feed, analysis *Analysis, now time.Time) error {
+// commonAuthor returns the author shared by all entries, or "" if the
+// entries have no author or more than one.
+func commonAuthor(entries []entry.Entry) string {
+	author := ""
+	for _, e := range entries {
+		switch {
+		case e.Author == "":
+			return ""
+		case author == "":
+			author = e.Author
+		case e.Author != author:
+			return ""
+		}
+	}
+	return author
+}
+
+// expandTagHierarchy returns a copy of feed whose entries also carry the
+// ancestors of their tags.
+func expandTagHierarchy(feed *entry.Feed, parents map[string]string) *entry.Feed {
+	expanded := *feed
+	expanded.Entries = make([]entry.Entry, len(feed.Entries))
+	for i, e := range feed.Entries {
+		e.Tags = entry.ExpandTagHierarchy(e.Tags, parents)
+		expanded.Entries[i] = e
+	}
+	return &expanded
+}
+
+func generateByTag(w *fileWriter, baseDir string, feed *entry.Feed, analysis *Analysis, cfg Config, now time.Time) error {
 	byTagDir := filepath.Join(baseDir, "by-tag")
 
+	parentOf := make(map[string]string, len(cfg.TagParents)) // lowercase child -> lowercase parent
+	for child, parent := range cfg.TagParents {
+		parentOf[strings.ToLower(child)] = strings.ToLower(parent)
+	}
+
 	// Group entries by tag (lowercase)
 	byTag := make(map[string][]entry.Entry)
-	tagTitles := make(map[string]string) // lowercase -> original case
+	tagTitles := analysis.TagTitles // lowercase -> canonical case
 
 	for _, e := range feed.Entries {
 		for _, tag := range e.Tags {
 			lower := strings.ToLower(tag)
 			byTag[lower] = append(byTag[lower], e)
-			if _, ok := tagTitles[lower]; !ok {
-				tagTitles[lower] = tag
-			}
 		}
 	}
 
 	// Generate index
 	var tagRefs []TagRef
-	for lower, entries := range byTag {
+	var jobs []func() error
+	files := make(map[string]string) // API path -> filename, for the index hash
+	for _, lower := range sortedKeys(byTag) {
+		entries := byTag[lower]
+		if len(entries) < cfg.MinTagCount {
+			continue
+		}
 		slug := Slugify(lower)
-		tagRefs = append(tagRefs, TagRef{
-			Tag:   tagTitles[lower],
-			Slug:  slug,
-			Count: len(entries),
-			Path:  fmt.Sprintf("/v1/by-tag/%s.json", slug),
-		})
+		path := apiPath(cfg, "by-tag/"+slug+".json")
+		oldest, latest := entryDateRange(entries)
+		ref := TagRef{
+			Tag:         tagTitles[lower],
+			Parent:      tagTitles[parentOf[lower]],
+			Slug:        slug,
+			Count:       len(entries),
+			LatestEntry: latest,
+			OldestEntry: oldest,
+			Path:        path,
+		}
 
 		// Generate tag file
 		tagFeed := &entry.Feed{
@@ -449,24 +859,149 @@ func generateByTag(baseDir string, feed *entry.Feed, analysis *Analysis, now tim
 			Entries:   entries,
 		}
 		jf := tagFeed.ToJSONFeed()
-		if err := jf.WriteFile(filepath.Join(byTagDir, slug+".json")); err != nil {
-			return err
+		jf.FeedURL = absoluteURL(cfg, path)
+		jf.Language = cfg.DefaultLanguage
+		if lang, _ := entry.DominantLanguage(entries); lang != "" {
+			jf.Language = lang
+		}
+		filename := filepath.Join(byTagDir, slug+".json")
+		jobs = append(jobs, func() error { return w.writeFeed(filename, jf) })
+		files[path] = filename
+
+		if cfg.GenerateTagFeeds {
+			formats := cfg.TagFeedFormats
+			if len(formats) == 0 {
+				formats = []string{"atom", "rss"}
+			}
+			linked := *tagFeed
+			linked.HomeURL = cfg.PlanetURL
+			feedJobs, err := generateFeedFiles(w, byTagDir, slug, &linked, formats, atom.Options{
+				AuthorName:  cfg.OwnerName,
+				AuthorEmail: cfg.OwnerEmail,
+				AuthorURI:   cfg.OwnerURL,
+			}, &ref.AtomPath, &ref.RSSPath, cfg)
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, feedJobs...)
 		}
+		tagRefs = append(tagRefs, ref)
+	}
+	if err := runJobs(cfg.WriteConcurrency, jobs); err != nil {
+		return err
 	}
 
-	sort.Slice(tagRefs, func(i, j int) bool {
+	sort.SliceStable(tagRefs, func(i, j int) bool {
 		return tagRefs[i].Count > tagRefs[j].Count
 	})
 
 	index := TagIndex{
 		Generated: now,
 		Count:     len(tagRefs),
+		Hash:      w.indexHash(files),
 		Tags:      tagRefs,
 	}
-	return writeJSON(filepath.Join(byTagDir, "index.json"), index)
+	return w.writeJSON(filepath.Join(byTagDir, "index.json"), index)
+}
+
+// generateFeedFiles renders feed in formats ("atom", "rss") as
+// {slug}.atom.xml and {slug}.rss.xml in dir, a by-source or by-tag
+// directory, records their API paths in atomPath and rssPath, and returns
+// jobs that write them. opts sets the Atom feed's author and icon. Without
+// a planet URL, the Atom ID is a urn: naming the source or tag.
+func generateFeedFiles(w *fileWriter, dir, slug string, feed *entry.Feed, formats []string, opts atom.Options, atomPath, rssPath *string, cfg Config) ([]func() error, error) {
+	group := filepath.Base(dir)              // "by-source" or "by-tag"
+	kind := strings.TrimPrefix(group, "by-") // "source" or "tag"
+	var jobs []func() error
+	for _, format := range formats {
+		var name string
+		var data []byte
+		var err error
+		switch strings.ToLower(format) {
+		case "atom":
+			name = slug + ".atom.xml"
+			*atomPath = apiPath(cfg, group+"/"+name)
+			feedURL := absoluteURL(cfg, *atomPath)
+			af := atom.FromFeedWithOptions(feed, feedURL, opts)
+			if feedURL == "" {
+				af.ID = "urn:signal:" + kind + ":" + slug
+			}
+			if data, err = af.ToXML(); err == nil {
+				data = append([]byte(xml.Header), data...)
+			}
+		case "rss":
+			name = slug + ".rss.xml"
+			*rssPath = apiPath(cfg, group+"/"+name)
+			data, err = rss.FromFeed(feed, absoluteURL(cfg, *rssPath)).ToXML()
+		default:
+			return nil, fmt.Errorf("unknown %s feed format %q (want atom or rss)", kind, format)
+		}
+		if err != nil {
+			return nil, err
+		}
+		filename := filepath.Join(dir, name)
+		jobs = append(jobs, func() error { return w.writeCounted(filename, data, len(feed.Entries)) })
+	}
+	return jobs, nil
+}
+
+// generateManifest writes meta/manifest.json, listing every file written
+// so far (or left unchanged) by its API path.
+func generateManifest(w *fileWriter, baseDir string, cfg Config, now time.Time) error {
+	w.mu.Lock()
+	files := make(map[string]string, len(w.hashes)) // API path -> filename
+	counts := make(map[string]int, len(w.counts))
+	for filename := range w.hashes {
+		rel, err := filepath.Rel(baseDir, filename)
+		if err != nil {
+			w.mu.Unlock()
+			return err
+		}
+		path := apiPath(cfg, filepath.ToSlash(rel))
+		files[path] = filename
+		if n, ok := w.counts[filename]; ok {
+			counts[path] = n
+		}
+	}
+	w.mu.Unlock()
+
+	manifest := Manifest{
+		Generated: now,
+		Hash:      w.indexHash(files),
+		Files:     []FileRef{},
+	}
+	for _, path := range sortedKeys(files) {
+		ref := FileRef{Path: path, Type: fileType(path)}
+		if n, ok := counts[path]; ok {
+			ref.Count = &n
+		}
+		manifest.Files = append(manifest.Files, ref)
+	}
+	manifest.Count = len(manifest.Files)
+	return w.writeJSON(filepath.Join(baseDir, "meta", "manifest.json"), manifest)
+}
+
+// fileType classifies an API file for the manifest by its path.
+func fileType(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".atom.xml"):
+		return "atom"
+	case strings.HasSuffix(path, ".rss.xml"):
+		return "rss"
+	case strings.HasSuffix(path, "/index.json"):
+		return "index"
+	case strings.HasSuffix(path, "/schema.json"):
+		return "schema"
+	case strings.HasSuffix(path, "/AGENTS.md"):
+		return "agents"
+	case strings.Contains(path, "/meta/"):
+		return "meta"
+	default:
+		return "feed"
+	}
 }
 
-func generateSchema(baseDir string) error {
+func generateSchema(w *fileWriter, baseDir string) error {
 	schema := map[string]interface{}{
 		"$schema":     "https://json-schema.org/draft/2020-12/schema",
 		"title":       "Signal API Schema",
@@ -477,10 +1012,12 @@ func generateSchema(baseDir string) error {
 				"properties": map[string]interface{}{
 					"id":             map[string]string{"type": "string"},
 					"url":            map[string]string{"type": "string", "format": "uri"},
+					"external_url":   map[string]string{"type": "string", "format": "uri"},
 					"title":          map[string]string{"type": "string"},
 					"date_published": map[string]string{"type": "string", "format": "date-time"},
 					"summary":        map[string]string{"type": "string"},
 					"content_html":   map[string]string{"type": "string"},
+					"content_text":   map[string]string{"type": "string"},
 					"authors": map[string]interface{}{
 						"type":  "array",
 						"items": map[string]string{"$ref": "#/$defs/author"},
@@ -505,9 +1042,9 @@ func generateSchema(baseDir string) error {
 			"feed": map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
-					"version":          map[string]string{"type": "string"},
-					"title":            map[string]string{"type": "string"},
-					"home_page_url":    map[string]string{"type": "string", "format": "uri"},
+					"version":           map[string]string{"type": "string"},
+					"title":             map[string]string{"type": "string"},
+					"home_page_url":     map[string]string{"type": "string", "format": "uri"},
 					"_signal_generated": map[string]string{"type": "string", "format": "date-time"},
 					"_signal_period":    map[string]string{"type": "string"},
 					"items": map[string]interface{}{
@@ -519,10 +1056,10 @@ func generateSchema(baseDir string) error {
 			},
 		},
 	}
-	return writeJSON(filepath.Join(baseDir, "schema.json"), schema)
+	return w.writeJSON(filepath.Join(baseDir, "schema.json"), schema)
 }
 
-func generateAgentsMD(baseDir string, cfg Config, analysis *Analysis, now time.Time) error {
+func generateAgentsMD(w *fileWriter, baseDir string, cfg Config, analysis *Analysis, now time.Time) error {
 	content := fmt.Sprintf(`# %s - Agent API Reference
 
 ## Overview
@@ -535,13 +1072,14 @@ All data is static JSON following the [JSON Feed 1.1](https://jsonfeed.org/versi
 
 | Task | Path |
 |------|------|
-| Latest entries | ` + "`/v1/feeds/latest.json`" + ` |
-| All sources | ` + "`/v1/meta/sources.json`" + ` |
-| Statistics | ` + "`/v1/meta/stats.json`" + ` |
-| Schema | ` + "`/v1/schema.json`" + ` |
-| Entries by source | ` + "`/v1/by-source/{slug}.json`" + ` |
-| Entries by month | ` + "`/v1/by-month/{YYYY-MM}.json`" + ` |
-| Entries by tag | ` + "`/v1/by-tag/{tag}.json`" + ` |
+| Latest entries | `+"`/v1/feeds/latest.json`"+` |
+%s| All sources | `+"`/v1/meta/sources.json`"+` |
+| Statistics | `+"`/v1/meta/stats.json`"+` |
+| Every file | `+"`/v1/meta/manifest.json`"+` |
+| Schema | `+"`/v1/schema.json`"+` |
+| Entries by source | `+"`/v1/by-source/{slug}.json`"+` |
+| Entries by month | `+"`/v1/by-month/%s`"+` |
+| Entries by tag | `+"`/v1/by-tag/{tag}.json`"+` |
 
 ## Statistics
 
@@ -554,11 +1092,12 @@ All data is static JSON following the [JSON Feed 1.1](https://jsonfeed.org/versi
 
 | Source | Entries | Path |
 |--------|---------|------|
-`, cfg.PlanetName, cfg.PlanetName, analysis.TotalEntries, analysis.TotalSources, analysis.TotalTags,
+`, cfg.PlanetName, cfg.PlanetName, recentRow(cfg), monthFilePath(cfg, "{YYYY}-{MM}"), analysis.TotalEntries, analysis.TotalSources, analysis.TotalTags,
 		analysis.OldestEntry.Format("2006-01-02"), analysis.NewestEntry.Format("2006-01-02"))
 
 	// Add sources table
-	for title, sa := range analysis.EntriesBySource {
+	for _, title := range sortedKeys(analysis.EntriesBySource) {
+		sa := analysis.EntriesBySource[title]
 		content += fmt.Sprintf("| %s | %d | `/%s/by-source/%s.json` |\n",
 			title, sa.Count, cfg.Version, sa.Slug)
 	}
@@ -569,9 +1108,37 @@ All data is static JSON following the [JSON Feed 1.1](https://jsonfeed.org/versi
 1. Start with ` + "`/v1/meta/about.json`" + ` for planet metadata
 2. Use ` + "`/v1/meta/sources.json`" + ` to list all sources
 3. Use ` + "`/v1/meta/stats.json`" + ` for aggregate statistics
-4. Use index files (` + "`index.json`" + `) to discover available paths
+4. Use index files (` + "`index.json`" + `) to discover available paths, or ` + "`/v1/meta/manifest.json`" + ` to list every file at once
 5. Construct paths directly: ` + "`/v1/by-source/{slug}.json`" + `
 
+## Pagination and Filtering
+
+There are no query parameters: filter by choosing the narrowest file, and page by following links between files.
+
+- **Paging**: ` + "`/v1/feeds/latest.json`" + ` holds only the latest months. For older entries, ` + monthIndexHint(cfg) + ` and fetch each ` + "`path`" + ` in turn. If a feed has a ` + "`next_url`" + `, follow it for the next page and stop when it is absent.
+- **By source**: ` + "`/v1/by-source/index.json`" + ` lists each source's ` + "`slug`" + `, ` + "`count`" + `, and ` + "`path`" + `. Slugs match ` + "`/v1/meta/sources.json`" + `.
+- **By tag**: ` + "`/v1/by-tag/index.json`" + ` lists tags with lowercase slugs. Tag matching is case-insensitive, and rare tags may have no page.
+- **Combining filters**: Fetch the narrowest file (a tag or source), then filter its items client-side by ` + "`date_published`" + `, ` + "`tags`" + `, or ` + "`_signal_feed_title`" + `.
+- **Search**: There is no search index. Scan item ` + "`title`" + `, ` + "`summary`" + `, and ` + "`content_text`" + ` instead.
+
+Items within every feed are sorted newest first.
+` + pollingSection(cfg) + `
+## Sample Fetch Sequences
+
+**Latest posts from one source:**
+
+1. ` + "`GET /v1/by-source/index.json`" + ` and find the source's ` + "`path`" + `
+2. ` + "`GET`" + ` that path; the first items are the newest
+
+**Posts on a tag during one month:**
+
+1. ` + "`GET /v1/by-tag/index.json`" + ` and find the tag's ` + "`path`" + `
+2. ` + "`GET`" + ` that path and keep items whose ` + "`date_published`" + ` starts with ` + "`YYYY-MM`" + `
+
+**Full history:**
+
+` + fullHistorySteps(cfg) + `
+
 ## Entry Structure
 
 Each entry in a feed follows JSON Feed 1.1 with Signal extensions:
@@ -592,30 +1159,35 @@ Each entry in a feed follows JSON Feed 1.1 with Signal extensions:
 }
 ` + "```" + `
 
-## Orbit Extensions
-
-Fields prefixed with ` + "`_signal_`" + ` are Orbit-specific:
-
-| Field | Description |
-|-------|-------------|
-| ` + "`_orbit_generated`" + ` | When the feed was generated |
-| ` + "`_orbit_period`" + ` | Month period for monthly archives (e.g., "2026-02") |
-| ` + "`_orbit_feed_title`" + ` | Title of the source feed |
-| ` + "`_orbit_feed_url`" + ` | URL of the source feed |
-| ` + "`_orbit_priority`" + ` | Whether this is a hand-curated priority entry |
+## Signal Extensions
 
----
+Fields prefixed with ` + "`_signal_`" + ` are Signal-specific:
 
+| Field | Level | Description |
+|-------|-------|-------------|
 `
+	for _, ext := range jsonfeed.Extensions() {
+		content += fmt.Sprintf("| `%s` | %s | %s |\n", ext.Field, ext.Level, ext.Description)
+	}
+
+	content += "\n---\n\n"
 	content += fmt.Sprintf("Generated: %s\nGenerator: Signal %s\n", now.Format(time.RFC3339), SignalVersion)
 
-	return os.WriteFile(filepath.Join(baseDir, "AGENTS.md"), []byte(content), 0644)
+	return w.write(filepath.Join(baseDir, "AGENTS.md"), []byte(content))
 }
 
-func writeJSON(filename string, v interface{}) error {
-	data, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		return err
+// apiPath returns the API path of a file relative to the version directory,
+// e.g., "by-tag/go.json" → "/v1/by-tag/go.json".
+func apiPath(cfg Config, rel string) string {
+	return "/" + cfg.Version + "/" + rel
+}
+
+// absoluteURL returns the public URL for an API path, matching the
+// "{PlanetURL}/data{path}" layout used by about.json. It returns "" when
+// no PlanetURL is configured.
+func absoluteURL(cfg Config, path string) string {
+	if cfg.PlanetURL == "" {
+		return ""
 	}
-	return os.WriteFile(filename, data, 0644)
+	return strings.TrimRight(cfg.PlanetURL, "/") + "/data" + path
 }
@@ -1,8 +1,34 @@
 package api
 
+import (
+	"os"
+	"time"
+)
+
 // Version is the current API version.
 const Version = "v1"
 
+// Default permissions for generated files, and for created directories
+// (before umask).
+const (
+	DefaultFileMode os.FileMode = 0644
+	DefaultDirMode  os.FileMode = 0755
+)
+
+// DefaultTopTagsLimit is the number of tags listed in stats.json when
+// Config.TopTagsLimit is not set.
+const DefaultTopTagsLimit = 20
+
+// DefaultStaleDays is the number of days without a new entry after which a
+// source is listed in stats.json stale_sources when Config.StaleDays is
+// not set.
+const DefaultStaleDays = 180
+
+// DefaultWriteConcurrency is the number of by-month, by-source, and by-tag
+// files written at once when Config.WriteConcurrency is not set: one, so
+// files are written, and logged, in the same order on every run.
+const DefaultWriteConcurrency = 1
+
 // Config holds configuration for API generation.
 type Config struct {
 	// Version is the API version (e.g., "v1")
@@ -15,16 +41,67 @@ type Config struct {
 	PlanetName        string
 	PlanetDescription string
 	PlanetURL         string
+	DefaultLanguage   string // Feed-level language (e.g., "en") of planet feeds, and of source and tag feeds whose entries declare none
+	IconURL           string // Large square planet icon for feeds/latest.json (JSON Feed icon)
+	FaviconURL        string // Small planet icon for feeds/latest.json (JSON Feed favicon)
 
 	// Owner metadata
-	OwnerName string
-	OwnerURL  string
+	OwnerName   string
+	OwnerEmail  string // Atom author email of tag feeds, and of source feeds without a common author
+	OwnerURL    string
+	OwnerAvatar string
 
 	// Generation options
 	GenerateAll      bool // Generate feeds/all.json (can be large)
 	GenerateSchema   bool // Generate schema.json
 	GenerateAgentsMD bool // Generate AGENTS.md
 	LatestMonths     int  // Number of months in feeds/latest.json
+	MaxTotal         int  // Cap on entries in feeds/latest.json, newest first (0 = unlimited)
+	RecentDays       int  // Write feeds/recent.json with the last N days of entries for pollers (0 = don't)
+	SkipUnchanged    bool // Don't rewrite files whose content only differs by generation time
+	NestByYear       bool // Write by-month/{YYYY}/{MM}.json with per-year indexes instead of by-month/{YYYY-MM}.json
+	PreserveMeta     bool // Merge into an existing meta/about.json, keeping hand-added keys
+
+	// Permissions for written files and created directories (0 = DefaultFileMode, DefaultDirMode)
+	FileMode os.FileMode
+	DirMode  os.FileMode
+
+	// WriteConcurrency is the number of by-month, by-source, and by-tag
+	// files written at once (0 = DefaultWriteConcurrency, sequential).
+	// Higher values speed up writes to slow or network filesystems, at the
+	// cost of a deterministic write order.
+	WriteConcurrency int
+
+	// GeneratedAt overrides the generation timestamp (zero = time.Now) so
+	// unchanged content produces byte-identical output
+	GeneratedAt time.Time
+
+	// OmitGenerated leaves _signal_generated out of JSON Feed files, so
+	// they don't change between runs or reveal the build schedule
+	OmitGenerated bool
+
+	// GenerateSourceFeeds lists the formats of per-source feeds to write
+	// next to by-source/{slug}.json: "atom", "rss" (empty = none)
+	GenerateSourceFeeds []string
+
+	// StaleDays is the age in days of a source's newest entry after which
+	// stats.json lists it in stale_sources (0 = DefaultStaleDays)
+	StaleDays int
+
+	// Tag options
+	MinTagCount      int      // Tags on fewer entries get no by-tag page (still kept on entries)
+	TopTagsLimit     int      // Number of tags in stats.json top_tags (0 = DefaultTopTagsLimit)
+	GenerateTagFeeds bool     // Also write by-tag/{slug}.atom.xml and/or .rss.xml
+	TagFeedFormats   []string // Tag feed formats: "atom", "rss" (empty = both)
+
+	// TagParents maps child tags to parent tags (e.g., "RAG" → "AI").
+	// Entries count under every ancestor of their tags in stats and by-tag,
+	// so a parent's by-tag page includes its descendants' entries.
+	TagParents map[string]string
+
+	// onWrite, if set, is called with each filename as it is written, in
+	// write order; tests use it to record the order
+	onWrite func(filename string)
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -36,5 +113,10 @@ func DefaultConfig() Config {
 		GenerateSchema:   true,
 		GenerateAgentsMD: true,
 		LatestMonths:     3,
+		TopTagsLimit:     DefaultTopTagsLimit,
+		StaleDays:        DefaultStaleDays,
+		FileMode:         DefaultFileMode,
+		DirMode:          DefaultDirMode,
+		WriteConcurrency: DefaultWriteConcurrency,
 	}
 }
@@ -8,6 +8,7 @@ import (
 type MonthIndex struct {
 	Generated time.Time  `json:"generated"`
 	Count     int        `json:"count"`
+	Hash      string     `json:"hash"` // Changes when any referenced file changes
 	Months    []MonthRef `json:"months"`
 }
 
@@ -18,32 +19,76 @@ type MonthRef struct {
 	Path  string `json:"path"`
 }
 
+// YearIndex lists the years of monthly archives when they are nested by year.
+type YearIndex struct {
+	Generated time.Time `json:"generated"`
+	Count     int       `json:"count"`
+	Hash      string    `json:"hash"` // Changes when any referenced file changes
+	Years     []YearRef `json:"years"`
+}
+
+// YearRef references a year's index of monthly archives.
+type YearRef struct {
+	Year   string `json:"year"`
+	Months int    `json:"months"`
+	Count  int    `json:"count"`
+	Path   string `json:"path"`
+}
+
 // SourceIndex lists all available source feeds.
 type SourceIndex struct {
 	Generated time.Time   `json:"generated"`
 	Count     int         `json:"count"`
+	Hash      string      `json:"hash"` // Changes when any referenced file changes
 	Sources   []SourceRef `json:"sources"`
 }
 
 // SourceRef references a source feed file.
 type SourceRef struct {
-	Slug  string `json:"slug"`
-	Title string `json:"title"`
-	Count int    `json:"count"`
-	Path  string `json:"path"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Count       int       `json:"count"`
+	LatestEntry time.Time `json:"latest_entry,omitempty"` // For sorting sources by activity
+	OldestEntry time.Time `json:"oldest_entry,omitempty"`
+	Path        string    `json:"path"`
+	AtomPath    string    `json:"atom_path,omitempty"`
+	RSSPath     string    `json:"rss_path,omitempty"`
 }
 
 // TagIndex lists all available tag feeds.
 type TagIndex struct {
 	Generated time.Time `json:"generated"`
 	Count     int       `json:"count"`
+	Hash      string    `json:"hash"` // Changes when any referenced file changes
 	Tags      []TagRef  `json:"tags"`
 }
 
 // TagRef references a tag feed file.
 type TagRef struct {
-	Tag   string `json:"tag"`
-	Slug  string `json:"slug"`
-	Count int    `json:"count"`
+	Tag    string `json:"tag"`
+	Parent string `json:"parent,omitempty"` // Parent tag, when a tag hierarchy is configured
+	Slug   string `json:"slug"`
+	Count  int    `json:"count"`
+	// LatestEntry and OldestEntry let clients sort tags by activity
+	LatestEntry time.Time `json:"latest_entry,omitempty"`
+	OldestEntry time.Time `json:"oldest_entry,omitempty"`
+	Path        string    `json:"path"`
+	AtomPath    string    `json:"atom_path,omitempty"`
+	RSSPath     string    `json:"rss_path,omitempty"`
+}
+
+// Manifest lists every file of the API with its type and, for feeds, its
+// entry count, so a client can discover the whole tree from one file.
+type Manifest struct {
+	Generated time.Time `json:"generated"`
+	Count     int       `json:"count"`
+	Hash      string    `json:"hash"` // Changes when any listed file changes
+	Files     []FileRef `json:"files"`
+}
+
+// FileRef references a file in the manifest.
+type FileRef struct {
 	Path  string `json:"path"`
+	Type  string `json:"type"`            // "feed", "atom", "rss", "index", "meta", "schema", or "agents"
+	Count *int   `json:"count,omitempty"` // Entries, for feeds
 }
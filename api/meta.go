@@ -6,20 +6,21 @@ import (
 
 // AboutMeta contains metadata about the planet.
 type AboutMeta struct {
-	Name        string     `json:"name"`
-	Description string     `json:"description,omitempty"`
-	HomeURL     string     `json:"home_url,omitempty"`
-	FeedURL     string     `json:"feed_url,omitempty"`
-	AtomURL     string     `json:"atom_url,omitempty"`
-	Owner       *Owner     `json:"owner,omitempty"`
-	Generated   time.Time  `json:"generated"`
-	Generator   Generator  `json:"generator"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	HomeURL     string    `json:"home_url,omitempty"`
+	FeedURL     string    `json:"feed_url,omitempty"`
+	AtomURL     string    `json:"atom_url,omitempty"`
+	Owner       *Owner    `json:"owner,omitempty"`
+	Generated   time.Time `json:"generated"`
+	Generator   Generator `json:"generator"`
 }
 
 // Owner contains information about the planet owner.
 type Owner struct {
-	Name string `json:"name"`
-	URL  string `json:"url,omitempty"`
+	Name   string `json:"name"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
 }
 
 // Generator contains information about the software that generated the output.
@@ -60,6 +61,7 @@ type StatsMeta struct {
 	EntriesByMonth  []MonthCount  `json:"entries_by_month"`
 	EntriesBySource []SourceCount `json:"entries_by_source"`
 	TopTags         []TagCount    `json:"top_tags"`
+	StaleSources    []StaleSource `json:"stale_sources"`
 }
 
 // DateRange represents a range of dates.
@@ -81,6 +83,15 @@ type SourceCount struct {
 	Count int    `json:"count"`
 }
 
+// StaleSource is a source whose newest entry is older than the stale
+// threshold, likely an abandoned blog.
+type StaleSource struct {
+	Slug            string    `json:"slug"`
+	Title           string    `json:"title"`
+	LastSeen        time.Time `json:"last_seen"`
+	DaysSinceNewest int       `json:"days_since_newest"`
+}
+
 // TagCount represents entry count for a tag.
 type TagCount struct {
 	Tag   string `json:"tag"`
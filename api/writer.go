@@ -0,0 +1,200 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/grokify/signal/internal/fileutil"
+	"github.com/grokify/signal/jsonfeed"
+)
+
+// Report summarizes the files written by GenerateWithReport.
+type Report struct {
+	Written int // Files written
+	Skipped int // Files left untouched because their content was unchanged
+}
+
+// fileWriter writes API files, optionally skipping files whose content is
+// unchanged apart from generation timestamps. It is safe for concurrent use.
+type fileWriter struct {
+	skipUnchanged bool
+	omitGenerated bool
+	fileMode      os.FileMode
+	onWrite       func(filename string) // Called under mu with each file, in write order
+	mu            sync.Mutex            // guards the fields below
+	written       int
+	skipped       int
+	hashes        map[string]string // filename -> contentHash of its data
+	counts        map[string]int    // filename -> entries, for feeds
+}
+
+// generatedLine matches timestamp lines that change on every run: the
+// "generated" and "_signal_generated" JSON fields, AGENTS.md's footer, and
+// the feed-level Atom <updated> and RSS <lastBuildDate> of tag feeds.
+var generatedLine = regexp.MustCompile(`(?m)^(\s*"(_signal_)?generated": ".*",?|Generated: .*|  <updated>.*</updated>|    <lastBuildDate>.*</lastBuildDate>)$`)
+
+func (w *fileWriter) write(filename string, data []byte) error {
+	hash := contentHash(data)
+	w.mu.Lock()
+	if w.hashes == nil {
+		w.hashes = make(map[string]string)
+	}
+	w.hashes[filename] = hash
+	if w.onWrite != nil {
+		w.onWrite(filename)
+	}
+	w.mu.Unlock()
+	if w.skipUnchanged {
+		if existing, err := os.ReadFile(filename); err == nil &&
+			bytes.Equal(generatedLine.ReplaceAll(existing, nil), generatedLine.ReplaceAll(data, nil)) {
+			if err := os.Chmod(filename, w.fileMode); err != nil {
+				return err
+			}
+			w.mu.Lock()
+			w.skipped++
+			w.mu.Unlock()
+			return nil
+		}
+	}
+	if err := fileutil.WriteFile(filename, data, w.fileMode); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.written++
+	w.mu.Unlock()
+	return nil
+}
+
+// runJobs runs jobs on at most n goroutines. Once a job fails no further
+// jobs are started, and the first error is returned after the running
+// ones finish.
+func runJobs(n int, jobs []func() error) error {
+	if n < 1 {
+		n = 1
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, n)
+	for _, job := range jobs {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(job func() error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := job(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(job)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// contentHash returns a hex SHA-256 of data ignoring generation
+// timestamps, so it changes only when the content does.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(generatedLine.ReplaceAll(data, nil))
+	return hex.EncodeToString(sum[:])
+}
+
+// indexHash returns the hash published by an index: a digest of the API
+// paths and content hashes of the files it references, given as API path
+// -> filename. It changes whenever a file is added, removed, or changed.
+func (w *fileWriter) indexHash(files map[string]string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	h := sha256.New()
+	for _, path := range sortedKeys(files) {
+		fmt.Fprintf(h, "%s %s\n", path, w.hashes[files[path]])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (w *fileWriter) writeJSON(filename string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.write(filename, data)
+}
+
+// writeCounted writes a feed of n entries, recording n for the manifest.
+func (w *fileWriter) writeCounted(filename string, data []byte, n int) error {
+	w.mu.Lock()
+	if w.counts == nil {
+		w.counts = make(map[string]int)
+	}
+	w.counts[filename] = n
+	w.mu.Unlock()
+	return w.write(filename, data)
+}
+
+func (w *fileWriter) writeFeed(filename string, jf *jsonfeed.Feed) error {
+	if w.omitGenerated {
+		omitted := *jf
+		omitted.SignalGenerated = ""
+		jf = &omitted
+	}
+	data, err := jf.ToJSON()
+	if err != nil {
+		return err
+	}
+	return w.writeCounted(filename, data, len(jf.Items))
+}
+
+func (w *fileWriter) report() *Report {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return &Report{Written: w.written, Skipped: w.skipped}
+}
+
+// mergeExistingJSON overlays the fields of v onto the JSON object already
+// in filename, preserving keys v doesn't set, such as hand-added links. It
+// returns v unchanged if the file doesn't exist, and an error rather than
+// overwriting the file if it isn't a JSON object.
+func mergeExistingJSON(filename string, v interface{}) (interface{}, error) {
+	existing, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return v, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(existing, &merged); err != nil || merged == nil {
+		return nil, fmt.Errorf("cannot preserve %s: not a JSON object", filename)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generated map[string]interface{}
+	if err := json.Unmarshal(data, &generated); err != nil {
+		return nil, err
+	}
+	for k, val := range generated {
+		merged[k] = val
+	}
+	return merged, nil
+}
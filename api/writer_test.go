@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grokify/signal/entry"
+)
+
+// benchmarkFeed returns a feed of n entries spread over 24 months, 50
+// sources, and 200 tags, for a realistically wide API tree.
+func benchmarkFeed(n int) *entry.Feed {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feed := entry.NewFeed("Benchmark", "", "")
+	for i := 0; i < n; i++ {
+		source := i % 50
+		feed.Entries = append(feed.Entries, entry.Entry{
+			ID:      fmt.Sprintf("e%d", i),
+			Title:   fmt.Sprintf("Entry %d", i),
+			URL:     fmt.Sprintf("https://blog%d.example.com/posts/%d", source, i),
+			Date:    start.Add(time.Duration(i) * 8 * time.Hour),
+			Feed:    entry.FeedMeta{Title: fmt.Sprintf("Blog %d", source), URL: fmt.Sprintf("https://blog%d.example.com/", source)},
+			Tags:    []string{fmt.Sprintf("tag%d", i%200), fmt.Sprintf("tag%d", (i+7)%200)},
+			Summary: "A summary long enough to give each file some weight on disk.",
+		})
+	}
+	feed.SortByDate()
+	return feed
+}
+
+// BenchmarkGenerate compares sequential (the default) and concurrent
+// writes of the by-month, by-source, and by-tag files.
+func BenchmarkGenerate(b *testing.B) {
+	feed := benchmarkFeed(2000)
+	for _, concurrency := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			cfg := DefaultConfig()
+			cfg.OutputDir = b.TempDir()
+			cfg.GeneratedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			cfg.GenerateTagFeeds = true
+			cfg.GenerateSourceFeeds = []string{"atom", "rss"}
+			cfg.WriteConcurrency = concurrency
+			for b.Loop() {
+				if err := Generate(feed, nil, cfg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateWriteOrder asserts that two runs over the same feed write
+// the same files in the same order.
+func TestGenerateWriteOrder(t *testing.T) {
+	feed := benchmarkFeed(300)
+	run := func() []string {
+		var order []string
+		cfg := DefaultConfig()
+		cfg.onWrite = func(filename string) { order = append(order, filename) }
+		cfg.OutputDir = t.TempDir()
+		cfg.GeneratedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		cfg.GenerateTagFeeds = true
+		cfg.GenerateSourceFeeds = []string{"atom", "rss"}
+		if err := Generate(feed, nil, cfg); err != nil {
+			t.Fatal(err)
+		}
+		for i, filename := range order {
+			rel, err := filepath.Rel(cfg.OutputDir, filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			order[i] = rel
+		}
+		return order
+	}
+
+	first, second := run(), run()
+	if len(first) == 0 {
+		t.Fatal("no files written")
+	}
+	if !slices.Equal(first, second) {
+		t.Errorf("write order differs between runs:\n%q\n%q", first, second)
+	}
+
+	var months []string
+	for _, rel := range first {
+		if filepath.Base(filepath.Dir(rel)) == "by-month" {
+			months = append(months, rel)
+		}
+	}
+	if len(months) < 2 || !slices.IsSorted(months) {
+		t.Errorf("by-month files not written in sorted order: %q", months)
+	}
+}
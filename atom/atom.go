@@ -2,11 +2,18 @@
 package atom
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/xml"
+	"errors"
+	"fmt"
 	"os"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/internal/fileutil"
 )
 
 // Feed represents an Atom feed.
@@ -18,6 +25,8 @@ type Feed struct {
 	Updated string   `xml:"updated"`
 	ID      string   `xml:"id"`
 	Author  *Author  `xml:"author,omitempty"`
+	Icon    string   `xml:"icon,omitempty"`
+	Logo    string   `xml:"logo,omitempty"`
 	Entries []Entry  `xml:"entry"`
 }
 
@@ -37,14 +46,14 @@ type Author struct {
 
 // Entry represents an Atom entry element.
 type Entry struct {
-	Title     string   `xml:"title"`
-	Link      []Link   `xml:"link"`
-	ID        string   `xml:"id"`
-	Updated   string   `xml:"updated"`
-	Published string   `xml:"published,omitempty"`
-	Author    *Author  `xml:"author,omitempty"`
-	Summary   *Content `xml:"summary,omitempty"`
-	Content   *Content `xml:"content,omitempty"`
+	Title     string     `xml:"title"`
+	Link      []Link     `xml:"link"`
+	ID        string     `xml:"id"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published,omitempty"`
+	Author    *Author    `xml:"author,omitempty"`
+	Summary   *Content   `xml:"summary,omitempty"`
+	Content   *Content   `xml:"content,omitempty"`
 	Category  []Category `xml:"category,omitempty"`
 }
 
@@ -56,25 +65,58 @@ type Content struct {
 
 // Category represents an Atom category element.
 type Category struct {
-	Term string `xml:"term,attr"`
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+	Label  string `xml:"label,attr,omitempty"`
+}
+
+// Category schemes recording where an entry's tag came from.
+const (
+	SchemeOutline = "urn:signal:category:outline" // Assigned by the OPML outline
+	SchemeItem    = "urn:signal:category:item"    // From the feed item itself
+)
+
+// Options controls optional feed-level metadata in FromFeedWithOptions.
+type Options struct {
+	AuthorName  string // Feed author, typically the planet owner
+	AuthorEmail string
+	AuthorURI   string
+	IconURL     string // Small square icon, e.g., a favicon
+	LogoURL     string // Larger logo image
 }
 
 // FromFeed converts an entry.Feed to an Atom Feed.
 func FromFeed(f *entry.Feed, feedURL string) *Feed {
+	return FromFeedWithOptions(f, feedURL, Options{})
+}
+
+// FromFeedWithOptions is like FromFeed with a feed-level author and images.
+// When feedURL is empty the self link is omitted and the feed ID is a
+// stable urn derived from the title, so the output remains valid Atom.
+func FromFeedWithOptions(f *entry.Feed, feedURL string, opts Options) *Feed {
 	atomFeed := &Feed{
 		XMLNS:   "http://www.w3.org/2005/Atom",
 		Title:   f.Title,
 		Updated: f.Generated.Format(time.RFC3339),
 		ID:      feedURL,
-		Link: []Link{
-			{Href: feedURL, Rel: "self", Type: "application/atom+xml"},
-		},
 	}
+	if feedURL != "" {
+		atomFeed.Link = []Link{{Href: feedURL, Rel: "self", Type: "application/atom+xml"}}
+	} else {
+		atomFeed.ID = TitleID(f.Title)
+	}
+
+	if opts.AuthorName != "" {
+		atomFeed.Author = &Author{Name: opts.AuthorName, Email: opts.AuthorEmail, URI: opts.AuthorURI}
+	}
+	atomFeed.Icon = opts.IconURL
+	atomFeed.Logo = opts.LogoURL
 
 	if f.HomeURL != "" {
 		atomFeed.Link = append(atomFeed.Link, Link{Href: f.HomeURL, Rel: "alternate", Type: "text/html"})
 	}
 
+	labels := f.Stats().TagTitles
 	for _, e := range f.Entries {
 		atomEntry := Entry{
 			Title:     e.Title,
@@ -85,6 +127,9 @@ func FromFeed(f *entry.Feed, feedURL string) *Feed {
 				{Href: e.URL, Rel: "alternate", Type: "text/html"},
 			},
 		}
+		if e.ExternalURL != "" {
+			atomEntry.Link = append(atomEntry.Link, Link{Href: e.ExternalURL, Rel: "related", Type: "text/html"})
+		}
 
 		if e.Author != "" {
 			atomEntry.Author = &Author{Name: e.Author}
@@ -99,7 +144,11 @@ func FromFeed(f *entry.Feed, feedURL string) *Feed {
 		}
 
 		for _, tag := range e.Tags {
-			atomEntry.Category = append(atomEntry.Category, Category{Term: tag})
+			atomEntry.Category = append(atomEntry.Category, Category{
+				Term:   tag,
+				Scheme: tagScheme(e, tag),
+				Label:  labels[strings.ToLower(tag)],
+			})
 		}
 
 		atomFeed.Entries = append(atomFeed.Entries, atomEntry)
@@ -108,9 +157,123 @@ func FromFeed(f *entry.Feed, feedURL string) *Feed {
 	return atomFeed
 }
 
+// tagScheme returns the category scheme for one of e's tags.
+func tagScheme(e entry.Entry, tag string) string {
+	for _, t := range e.OutlineTags {
+		if strings.EqualFold(t, tag) {
+			return SchemeOutline
+		}
+	}
+	return SchemeItem
+}
+
+// TitleID returns a stable urn ID for a feed without a URL, derived from
+// its title.
+func TitleID(title string) string {
+	hash := sha256.Sum256([]byte(title))
+	return "urn:signal:feed:" + hex.EncodeToString(hash[:8])
+}
+
+// FromFeedPaged converts one page of an entry.Feed to an Atom Feed with
+// RFC 5005 paging links (first, last, previous, next). Pages are 1-indexed;
+// page 1 lives at feedURL and later pages at PageURL(feedURL, page).
+// A pageSize <= 0 disables paging and is equivalent to FromFeed.
+func FromFeedPaged(f *entry.Feed, feedURL string, page, pageSize int) *Feed {
+	return FromFeedPagedWithOptions(f, feedURL, page, pageSize, Options{})
+}
+
+// FromFeedPagedWithOptions is like FromFeedPaged with a feed-level author
+// and images.
+func FromFeedPagedWithOptions(f *entry.Feed, feedURL string, page, pageSize int, opts Options) *Feed {
+	if pageSize <= 0 {
+		return FromFeedWithOptions(f, feedURL, opts)
+	}
+	pages := PageCount(len(f.Entries), pageSize)
+	if page < 1 {
+		page = 1
+	} else if page > pages {
+		page = pages
+	}
+
+	start := (page - 1) * pageSize
+	end := start + pageSize
+	if end > len(f.Entries) {
+		end = len(f.Entries)
+	}
+	pageFeed := *f
+	pageFeed.Entries = f.Entries[start:end]
+
+	atomFeed := FromFeedWithOptions(&pageFeed, feedURL, opts)
+	if feedURL == "" {
+		return atomFeed
+	}
+
+	atomFeed.Link[0].Href = PageURL(feedURL, page)
+	atomFeed.Link = append(atomFeed.Link,
+		Link{Href: PageURL(feedURL, 1), Rel: "first", Type: "application/atom+xml"},
+		Link{Href: PageURL(feedURL, pages), Rel: "last", Type: "application/atom+xml"},
+	)
+	if page > 1 {
+		atomFeed.Link = append(atomFeed.Link, Link{Href: PageURL(feedURL, page-1), Rel: "previous", Type: "application/atom+xml"})
+	}
+	if page < pages {
+		atomFeed.Link = append(atomFeed.Link, Link{Href: PageURL(feedURL, page+1), Rel: "next", Type: "application/atom+xml"})
+	}
+	return atomFeed
+}
+
+// PageCount returns the number of pages needed for n entries. It is always
+// at least 1 so an empty feed still produces a single document.
+func PageCount(n, pageSize int) int {
+	if pageSize <= 0 || n <= pageSize {
+		return 1
+	}
+	return (n + pageSize - 1) / pageSize
+}
+
+// PageURL returns the URL or filename of the given page.
+// Page 1 is the name unchanged; later pages insert "-N" before the
+// extension (e.g., "atom.xml" → "atom-2.xml").
+func PageURL(name string, page int) string {
+	if page <= 1 {
+		return name
+	}
+	ext := path.Ext(name)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), page, ext)
+}
+
+// Validate checks that the feed and each entry have the non-empty id and
+// updated elements RFC 4287 requires.
+func (f *Feed) Validate() error {
+	if f.ID == "" {
+		return errors.New("atom: feed has an empty id")
+	}
+	if f.Updated == "" {
+		return errors.New("atom: feed has an empty updated time")
+	}
+	for i, e := range f.Entries {
+		if e.ID == "" {
+			return fmt.Errorf("atom: entry %d has an empty id", i)
+		}
+		if e.Updated == "" {
+			return fmt.Errorf("atom: entry %q has an empty updated time", e.ID)
+		}
+	}
+	return nil
+}
+
 // WriteFile writes the Atom feed to a file.
 func (f *Feed) WriteFile(filename string) error {
-	file, err := os.Create(filename)
+	return f.WriteFileMode(filename, 0644)
+}
+
+// WriteFileMode writes the Atom feed to a file with permissions perm. The
+// feed is validated first and nothing is written if it is invalid.
+func (f *Feed) WriteFileMode(filename string, perm os.FileMode) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	file, err := fileutil.Create(filename, perm)
 	if err != nil {
 		return err
 	}
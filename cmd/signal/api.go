@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/grokify/signal/api"
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/monthly"
+	"github.com/spf13/cobra"
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Regenerate the API structure from existing monthly files",
+	Long: `Rebuild the agent-friendly API structure from monthly files already on
+disk, without fetching any feeds. Use this after hand-editing or enriching
+monthly files.
+
+Source metadata is reconstructed from the entries themselves, since the
+OPML feed list may not be available, so sources have only a title and
+home page URL.`,
+	RunE: runAPI,
+}
+
+var (
+	apiDir           string
+	apiPrefix        string
+	apiCmdVersion    string
+	apiCmdConfigFile string
+)
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+
+	apiCmd.Flags().StringVar(&apiCmdConfigFile, "config", "", "Config file (YAML or JSON) keyed by flag name")
+	apiCmd.Flags().StringVar(&apiDir, "dir", "data", "Directory containing monthly files; the API is written here too")
+	apiCmd.Flags().StringVar(&apiPrefix, "prefix", "feeds", "Prefix of monthly files")
+	apiCmd.Flags().StringVar(&monthlyTemplate, "monthly-template", monthly.DefaultTemplate, "Monthly file name template the files were written with")
+	apiCmd.Flags().StringVar(&apiCmdVersion, "api-version", api.Version, "API version directory (e.g., 'v1')")
+	apiCmd.Flags().StringVar(&feedTitle, "title", "Signal Feed", "Feed title")
+	apiCmd.Flags().IntVar(&latestMonths, "latest-months", 3, "Number of months in latest feed (0=all)")
+	apiCmd.Flags().StringVar(&dedupScope, "dedup-scope", entry.DedupGlobal.String(), "URL dedup scope used when the monthly files were written: global or per-source")
+	apiCmd.Flags().IntVar(&maxTotal, "max-total", 0, "Max entries in feeds/latest.json, newest first (0=unlimited)")
+	apiCmd.Flags().StringVar(&fileMode, "file-mode", "0644", "Permissions for written output files (octal)")
+	apiCmd.Flags().StringVar(&dirMode, "dir-mode", "0755", "Permissions for created output directories (octal)")
+	apiCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	addAPIFlags(apiCmd)
+}
+
+func runAPI(cmd *cobra.Command, args []string) error {
+	if err := applyConfigFile(cmd, apiCmdConfigFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	generatedAt, err := sourceDateEpoch()
+	if err != nil {
+		return err
+	}
+	fMode, err := parseFileMode("file-mode", fileMode)
+	if err != nil {
+		return err
+	}
+	dMode, err := parseFileMode("dir-mode", dirMode)
+	if err != nil {
+		return err
+	}
+
+	scope, err := entry.ParseDedupScope(dedupScope)
+	if err != nil {
+		return err
+	}
+
+	if err := monthly.ValidateTemplate(monthlyTemplate); err != nil {
+		return err
+	}
+
+	entries, err := monthly.LoadExistingEntriesWithTemplate(apiDir, apiPrefix, monthlyTemplate, 0)
+	if err != nil {
+		return fmt.Errorf("failed to load monthly files: %w", err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no entries found in %s/%s", apiDir, monthly.TemplateFilename(monthlyTemplate, apiPrefix, "YYYY-MM"))
+	}
+
+	feed := entry.NewFeed(feedTitle, "", "")
+	if !generatedAt.IsZero() {
+		feed.Generated = generatedAt
+	}
+	feed.Entries = entries
+	feed.DeduplicateScoped(scope)
+	feed.SortByDate()
+	feed.NormalizeTagCase()
+	if verbose {
+		fmt.Printf("Loaded %d entries from %s\n", len(feed.Entries), apiDir)
+	}
+
+	cfg, err := newAPIConfig(apiCmdVersion, apiDir, generatedAt, fMode, dMode)
+	if err != nil {
+		return err
+	}
+	report, err := api.GenerateWithReport(feed, api.SourcesFromEntries(feed.Entries), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to generate API: %w", err)
+	}
+	if !quiet {
+		fmt.Printf("Generated API %s structure in %s (%d files written, %d unchanged)\n",
+			apiCmdVersion, apiDir, report.Written, report.Skipped)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// applyConfigFile populates a command's flags from a YAML or JSON file.
+// Keys are flag names (e.g., "output-dir", "max-entries"). Flags set
+// explicitly on the command line are left untouched, giving the precedence
+// explicit flag > config file > default.
+func applyConfigFile(cmd *cobra.Command, filename string) error {
+	if filename == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	default:
+		err = yaml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	// Apply in sorted order so errors are reported deterministically
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	flags := cmd.Flags()
+	for _, name := range keys {
+		if name == "config" {
+			continue
+		}
+		if flags.Lookup(name) == nil {
+			return fmt.Errorf("unknown config key %q in %s", name, filename)
+		}
+		if flags.Changed(name) {
+			continue
+		}
+		if err := flags.Set(name, configValueString(values[name])); err != nil {
+			return fmt.Errorf("invalid value for %q in %s: %w", name, filename, err)
+		}
+	}
+	return nil
+}
+
+// configValueString converts a decoded config value to its flag string form.
+// Lists are joined with commas to match slice flag syntax.
+func configValueString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []any:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			parts[i] = configValueString(item)
+		}
+		return strings.Join(parts, ",")
+	case time.Time:
+		return t.Format(time.RFC3339)
+	case float64:
+		// JSON numbers decode as float64; keep integers free of exponents
+		if t == float64(int64(t)) {
+			return fmt.Sprintf("%d", int64(t))
+		}
+		return fmt.Sprint(t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
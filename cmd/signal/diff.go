@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/monthly"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the entries of two output directories",
+	Long: `Compare the entries in the monthly files of two output directories by
+ID and report which were added, removed, or changed. Use this to review
+what a config or flag change did before publishing.`,
+	RunE: runDiff,
+}
+
+var (
+	diffOld    string
+	diffNew    string
+	diffPrefix string
+	diffJSON   bool
+)
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffOld, "old", "", "Old output directory")
+	diffCmd.Flags().StringVar(&diffNew, "new", "", "New output directory")
+	diffCmd.Flags().StringVar(&diffPrefix, "prefix", "feeds", "Prefix of monthly files")
+	diffCmd.Flags().StringVar(&monthlyTemplate, "monthly-template", monthly.DefaultTemplate, "Monthly file name template of both directories")
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "Print the full diff as JSON")
+	_ = diffCmd.MarkFlagRequired("old")
+	_ = diffCmd.MarkFlagRequired("new")
+}
+
+// entryDiff is the result of comparing two sets of entries by ID.
+type entryDiff struct {
+	Added   []diffEntry `json:"added"`
+	Removed []diffEntry `json:"removed"`
+	Changed []diffEntry `json:"changed"`
+}
+
+// diffEntry identifies an entry in an entryDiff.
+type diffEntry struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Fields []string `json:"fields,omitempty"` // Changed fields
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if err := monthly.ValidateTemplate(monthlyTemplate); err != nil {
+		return err
+	}
+	oldEntries, err := monthly.LoadExistingEntriesWithTemplate(diffOld, diffPrefix, monthlyTemplate, 0)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", diffOld, err)
+	}
+	newEntries, err := monthly.LoadExistingEntriesWithTemplate(diffNew, diffPrefix, monthlyTemplate, 0)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", diffNew, err)
+	}
+
+	d := diffEntries(oldEntries, newEntries)
+	if diffJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+
+	for _, e := range d.Added {
+		fmt.Printf("+ %s  %s\n", e.ID, e.Title)
+	}
+	for _, e := range d.Removed {
+		fmt.Printf("- %s  %s\n", e.ID, e.Title)
+	}
+	for _, e := range d.Changed {
+		fmt.Printf("~ %s  %s (%s)\n", e.ID, e.Title, strings.Join(e.Fields, ", "))
+	}
+	fmt.Printf("%d added, %d removed, %d changed\n", len(d.Added), len(d.Removed), len(d.Changed))
+	return nil
+}
+
+// diffEntries compares entries by ID. Each list is sorted newest first.
+func diffEntries(oldEntries, newEntries []entry.Entry) entryDiff {
+	oldByID := make(map[string]entry.Entry, len(oldEntries))
+	for _, e := range oldEntries {
+		oldByID[e.ID] = e
+	}
+	newByID := make(map[string]entry.Entry, len(newEntries))
+	for _, e := range newEntries {
+		newByID[e.ID] = e
+	}
+
+	d := entryDiff{Added: []diffEntry{}, Removed: []diffEntry{}, Changed: []diffEntry{}}
+	for _, e := range sortNewestFirst(newByID) {
+		old, ok := oldByID[e.ID]
+		if !ok {
+			d.Added = append(d.Added, diffEntry{ID: e.ID, Title: e.Title, URL: e.URL})
+		} else if fields := changedFields(old, e); len(fields) > 0 {
+			d.Changed = append(d.Changed, diffEntry{ID: e.ID, Title: e.Title, URL: e.URL, Fields: fields})
+		}
+	}
+	for _, e := range sortNewestFirst(oldByID) {
+		if _, ok := newByID[e.ID]; !ok {
+			d.Removed = append(d.Removed, diffEntry{ID: e.ID, Title: e.Title, URL: e.URL})
+		}
+	}
+	return d
+}
+
+// sortNewestFirst returns the entries of byID by date, newest first, with
+// ties broken by ID.
+func sortNewestFirst(byID map[string]entry.Entry) []entry.Entry {
+	entries := make([]entry.Entry, 0, len(byID))
+	for _, e := range byID {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.After(entries[j].Date)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// changedFields lists the JSON names of the fields that differ between
+// two versions of an entry.
+func changedFields(a, b entry.Entry) []string {
+	var fields []string
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	t := va.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
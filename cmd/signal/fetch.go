@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/grokify/signal/aggregator"
+	"github.com/grokify/signal/opml"
+	"github.com/spf13/cobra"
+)
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch <xmlUrl>",
+	Short: "Fetch a single feed and print its entries",
+	Long: `Fetch one feed URL with the default aggregator configuration and print
+its entries to stdout. Nothing is written to disk, so this is a quick way
+to test a feed before adding it to the OPML file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFetch,
+}
+
+var (
+	fetchMaxEntries int
+	fetchJSON       bool
+	fetchPretty     bool
+	fetchRaw        bool
+)
+
+func init() {
+	rootCmd.AddCommand(fetchCmd)
+
+	fetchCmd.Flags().IntVar(&fetchMaxEntries, "max-entries", 50, "Max entries to print (0=unlimited)")
+	fetchCmd.Flags().BoolVar(&fetchJSON, "json", true, "Print entries as JSON (false prints one line per entry)")
+	fetchCmd.Flags().BoolVar(&fetchPretty, "pretty", false, "Indent JSON output")
+	fetchCmd.Flags().BoolVar(&fetchRaw, "raw", false, "Include original feed item fields in JSON output")
+}
+
+func runFetch(cmd *cobra.Command, args []string) error {
+	cfg := aggregator.DefaultConfig()
+	cfg.MaxEntries = fetchMaxEntries
+	cfg.IncludeRaw = fetchRaw
+
+	agg := aggregator.New(cfg)
+	result := agg.FetchFeed(context.Background(), opml.Outline{XMLURL: args[0]})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if !fetchJSON {
+		for _, e := range result.Entries {
+			fmt.Printf("%s  %s\n    %s\n", e.Date.Format("2006-01-02"), e.Title, e.URL)
+		}
+		return nil
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	if fetchPretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(result.Entries)
+}
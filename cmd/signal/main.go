@@ -3,17 +3,26 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/grokify/mogo/fmt/progress"
 	"github.com/grokify/signal/aggregator"
 	"github.com/grokify/signal/api"
 	"github.com/grokify/signal/atom"
+	"github.com/grokify/signal/discussions"
 	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/internal/fileutil"
+	"github.com/grokify/signal/jsonfeed"
 	"github.com/grokify/signal/monthly"
 	"github.com/grokify/signal/opml"
 	"github.com/grokify/signal/priority"
@@ -22,15 +31,43 @@ import (
 
 var (
 	version = "dev"
+	quiet   bool
+)
+
+// Process exit codes. A partial failure means some feeds failed but
+// output was still written, so monitoring can tell it from a fatal error.
+const (
+	exitFatal          = 1
+	exitPartialFailure = 2
 )
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		var pf *partialFailureError
+		if errors.As(err, &pf) {
+			os.Exit(exitPartialFailure)
+		}
+		os.Exit(exitFatal)
 	}
 }
 
+// partialFailureError reports feeds that failed in a run that otherwise
+// completed and wrote its output.
+type partialFailureError struct {
+	errs  []error
+	total int
+}
+
+func (e *partialFailureError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d feeds failed:", len(e.errs), e.total)
+	for _, err := range e.errs {
+		fmt.Fprintf(&b, "\n  - %v", err)
+	}
+	return b.String()
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "signal",
 	Short: "Signal is a Planet-style blog aggregator that outputs JSON",
@@ -40,6 +77,12 @@ that can be consumed by React sites or other frontends.
 It reads feeds from an OPML file (in JSON format), fetches entries,
 and generates structured JSON output suitable for static site hosting.`,
 	Version: version,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// --quiet wins over --verbose
+		if quiet {
+			verbose = false
+		}
+	},
 }
 
 var aggregateCmd = &cobra.Command{
@@ -50,101 +93,498 @@ var aggregateCmd = &cobra.Command{
 }
 
 var (
-	opmlFile       string
-	priorityFile   string
-	outputDir      string
-	outputFile     string
-	atomFile       string
-	monthlyOutput  bool
-	monthlyPrefix  string
-	latestMonths   int
-	maxEntries     int
-	maxAgeDays     int
-	filterTags     []string
-	feedTitle      string
-	feedURL        string
-	concurrency    int
-	mergeExisting  bool
-	verbose        bool
+	configFile            string
+	opmlFile              string
+	priorityFiles         []string
+	priorityPinned        bool
+	sortOrder             string
+	expandEnv             bool
+	runSummary            string
+	outputStdout          bool
+	dedupReport           string
+	exportOPML            string
+	exportOPMLResolve     bool
+	exportOPMLDisable     bool
+	feedFilter            string
+	maxFeeds              int
+	includeDisabled       bool
+	opmlMaxDepth          int
+	outputDir             string
+	outputFile            string
+	atomFile              string
+	ndjsonFile            string
+	atomPageSize          int
+	monthlyOutput         bool
+	monthlyPrefix         string
+	monthlyTemplate       string
+	latestMonths          int
+	expireAfterMonths     int
+	maxEntries            int
+	maxContentBytes       int
+	cleanContent          bool
+	preferGUID            bool
+	maxTotal              int
+	recentDays            int
+	maxAgeDays            int
+	minDate               string
+	futureDates           string
+	futureSkew            time.Duration
+	filterTags            []string
+	feedTitle             string
+	feedURL               string
+	concurrency           int
+	fetchTimeout          time.Duration
+	connectTimeout        time.Duration
+	responseHeaderTimeout time.Duration
+	userAgent             string
+	dedupByTitle          bool
+	dedupBySummary        bool
+	stripBoilerplate      bool
+	boilerplateThreshold  float64
+	respectRobots         bool
+	opmlCategoriesAsTags  bool
+	preferOPMLTitle       bool
+	authorFromFeedTitle   bool
+	crawlDelay            time.Duration
+	fetchFavicons         bool
+	fetchImageDimensions  bool
+	fetchMetaSummary      bool
+	preferCanonical       bool
+	enrichHN              bool
+	enrichHNMax           int
+	absolutizeLinks       bool
+	includeRaw            bool
+	mergeExisting         bool
+	mergeStrategy         string
+	mergeWindowMonths     int
+	dedupScope            string
+	fileMode              string
+	dirMode               string
+	timings               bool
+	checkpoint            bool
+	resume                bool
+	cacheDir              string
+	verbose               bool
 
 	// API generation flags
-	apiVersion         string
-	planetName         string
-	planetDescription  string
-	planetURL          string
-	ownerName          string
-	ownerURL           string
-	generateAll        bool
-	generateSchema     bool
-	generateAgentsMD   bool
+	apiVersion        string
+	planetName        string
+	planetDescription string
+	planetURL         string
+	planetLanguage    string
+	planetIcon        string
+	planetFavicon     string
+	ownerName         string
+	ownerEmail        string
+	ownerURL          string
+	ownerAvatar       string
+	generateAll       bool
+	generateSchema    bool
+	generateAgentsMD  bool
+	minTagCount       int
+	topTagsLimit      int
+	staleDays         int
+	writeConcurrency  int
+	skipUnchanged     bool
+	omitGenerated     bool
+	preserveMeta      bool
+	nestByYear        bool
+	tagFeeds          bool
+	tagFeedFormats    []string
+	sourceFeeds       []string
+	tagHierarchy      string
 )
 
 func init() {
 	rootCmd.AddCommand(aggregateCmd)
 	rootCmd.AddCommand(initCmd)
 
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress non-error output")
+
+	aggregateCmd.Flags().StringVar(&configFile, "config", "", "Config file (YAML or JSON) keyed by flag name")
 	aggregateCmd.Flags().StringVarP(&opmlFile, "opml", "o", "feeds.json", "OPML file (JSON format)")
-	aggregateCmd.Flags().StringVarP(&priorityFile, "priority", "p", "", "Priority links file (JSON)")
+	aggregateCmd.Flags().StringSliceVarP(&priorityFiles, "priority", "p", nil, "Priority links files (JSON); repeat or use a glob, e.g., 'priority/*.json'")
+	aggregateCmd.Flags().BoolVar(&priorityPinned, "priority-pinned", false, "Pin priority links to the top of output, by rank, before date-sorted entries")
+	aggregateCmd.Flags().StringVar(&sortOrder, "sort", "desc", "Entry order of the output file: desc (newest first) or asc (oldest first)")
+	aggregateCmd.Flags().BoolVar(&expandEnv, "expand-env", false, "Expand ${VAR} references in OPML and priority URLs")
+	aggregateCmd.Flags().StringVar(&feedFilter, "feed-filter", "", "Only fetch feeds whose title or URL contains this substring")
+	aggregateCmd.Flags().IntVar(&maxFeeds, "max-feeds", 0, "Max number of feeds to fetch (0=all)")
+	aggregateCmd.Flags().BoolVar(&includeDisabled, "include-disabled", false, "Also fetch outlines marked disabled in the OPML")
+	aggregateCmd.Flags().IntVar(&opmlMaxDepth, "opml-max-depth", opml.DefaultMaxDepth, "Deepest OPML outline nesting allowed; deeper lists are rejected")
 	aggregateCmd.Flags().StringVarP(&outputDir, "output-dir", "d", "data", "Output directory")
 	aggregateCmd.Flags().StringVarP(&outputFile, "output", "f", "feeds.json", "Output JSON filename")
+	aggregateCmd.Flags().BoolVar(&outputStdout, "output-stdout", false, "Write the JSON Feed to stdout instead of the output file, with no progress output")
+	aggregateCmd.Flags().StringVar(&runSummary, "run-summary", "run.json", "Run summary JSON filename in the output dir (empty to disable)")
+	aggregateCmd.Flags().StringVar(&ndjsonFile, "ndjson", "", "Also write all entries as newline-delimited JSON to this file in the output dir")
+	aggregateCmd.Flags().StringVar(&dedupReport, "dedup-report", "", "Write entries found in multiple feeds to this JSON file in the output dir")
+	aggregateCmd.Flags().StringVar(&exportOPML, "export-opml", "", "Write an OPML feed list of the feeds that fetched successfully (.json for JSON, else XML)")
+	aggregateCmd.Flags().BoolVar(&exportOPMLResolve, "export-opml-resolve", false, "Use titles and home page URLs from the fetched feeds in --export-opml")
+	aggregateCmd.Flags().BoolVar(&exportOPMLDisable, "export-opml-disable-failed", false, "Keep failed feeds in --export-opml, marked disabled")
 	aggregateCmd.Flags().StringVar(&atomFile, "atom", "", "Generate Atom feed file")
+	aggregateCmd.Flags().IntVar(&atomPageSize, "atom-page-size", 0, "Entries per Atom page, writing atom-2.xml etc. (0=single file)")
 	aggregateCmd.Flags().BoolVar(&monthlyOutput, "monthly", false, "Split output into monthly files")
 	aggregateCmd.Flags().StringVar(&monthlyPrefix, "monthly-prefix", "feeds", "Prefix for monthly files")
+	aggregateCmd.Flags().StringVar(&monthlyTemplate, "monthly-template", monthly.DefaultTemplate, "Monthly file name template using {prefix}, {year}, {month}; may nest directories")
 	aggregateCmd.Flags().IntVar(&latestMonths, "latest-months", 3, "Number of months in latest feed (0=all)")
+	aggregateCmd.Flags().IntVar(&expireAfterMonths, "expire-after-months", 0, "Mark monthly files older than N months as expired (0=never)")
 	aggregateCmd.Flags().IntVar(&maxEntries, "max-entries", 50, "Max entries per feed")
+	aggregateCmd.Flags().BoolVar(&cleanContent, "clean-content", false, "Strip HTML comments, tracking pixels, and empty div/span/p elements from entry content")
+	aggregateCmd.Flags().IntVar(&maxContentBytes, "max-content-bytes", 0, "Truncate entry content (HTML-aware) beyond this many characters (0=unlimited)")
+	aggregateCmd.Flags().IntVar(&maxTotal, "max-total", 0, "Max entries in the latest/single-file output, newest first (0=unlimited)")
 	aggregateCmd.Flags().IntVar(&maxAgeDays, "max-age", 0, "Max entry age in days (0=unlimited)")
+	aggregateCmd.Flags().StringVar(&minDate, "min-date", "", "Drop entries dated before this YYYY-MM-DD as bogus timestamps")
+	aggregateCmd.Flags().StringVar(&futureDates, "future-dates", aggregator.FutureKeep.String(), "Entries dated in the future: keep, drop, or clamp (date them at fetch time)")
+	aggregateCmd.Flags().DurationVar(&futureSkew, "future-skew", aggregator.DefaultFutureSkew, "How far ahead an entry may be dated before --future-dates applies")
 	aggregateCmd.Flags().StringSliceVar(&filterTags, "tags", nil, "Filter by tags")
 	aggregateCmd.Flags().StringVar(&feedTitle, "title", "Signal Feed", "Feed title")
 	aggregateCmd.Flags().StringVar(&feedURL, "url", "", "Feed URL for Atom output")
-	aggregateCmd.Flags().IntVar(&concurrency, "concurrency", 10, "Concurrent feed fetches")
+	aggregateCmd.Flags().IntVar(&concurrency, "concurrency", 10, "Concurrent feed fetches (1=sequential, 0=unbounded)")
+	aggregateCmd.Flags().DurationVar(&fetchTimeout, "timeout", 30*time.Second, "Overall timeout for each feed fetch")
+	aggregateCmd.Flags().DurationVar(&connectTimeout, "connect-timeout", 0, "Timeout for connecting to a feed host (0=same as --timeout)")
+	aggregateCmd.Flags().DurationVar(&responseHeaderTimeout, "response-header-timeout", 0, "Timeout waiting for response headers (0=same as --timeout)")
+	aggregateCmd.Flags().StringVar(&userAgent, "user-agent", aggregator.DefaultUserAgent, "User-Agent for feed requests")
+	aggregateCmd.Flags().BoolVar(&respectRobots, "respect-robots", true, "Honor robots.txt when fetching article pages")
+	aggregateCmd.Flags().BoolVar(&preferOPMLTitle, "prefer-opml-title", false, "Name sources by their OPML outline title instead of the feed's own title")
+	aggregateCmd.Flags().BoolVar(&authorFromFeedTitle, "author-from-feed-title", false, "Credit entries without an author (or outline defaultAuthor) to their feed's title")
+	aggregateCmd.Flags().BoolVar(&opmlCategoriesAsTags, "opml-categories-as-tags", true, "Add OPML outline categories to the tags of their entries")
+	aggregateCmd.Flags().DurationVar(&crawlDelay, "crawl-delay", time.Second, "Minimum delay between article page fetches per host")
+	aggregateCmd.Flags().BoolVar(&includeRaw, "include-raw", false, "Include original feed item fields in output as _signal_raw (for debugging)")
+	aggregateCmd.Flags().BoolVar(&absolutizeLinks, "absolutize-links", false, "Rewrite relative links and images in entry content to absolute URLs")
+	aggregateCmd.Flags().BoolVar(&fetchMetaSummary, "fetch-meta-summary", false, "Use the article's meta description as summary when a feed item has no text")
+	aggregateCmd.Flags().BoolVar(&preferGUID, "prefer-guid", false, "Derive entry IDs from item GUIDs when present (changes IDs of stored entries)")
+	aggregateCmd.Flags().BoolVar(&preferCanonical, "prefer-canonical-url", false, "Use each article's rel=canonical or og:url as its URL, keeping the feed link as external_url (fetches article pages)")
+	aggregateCmd.Flags().BoolVar(&enrichHN, "enrich-hn", false, "Attach Hacker News discussion links (score, comments) to fetched entries via the HN Algolia API")
+	aggregateCmd.Flags().IntVar(&enrichHNMax, "enrich-hn-max", discussions.DefaultHackerNewsMaxLookups, "Max Hacker News lookups per run, newest entries first")
+	aggregateCmd.Flags().BoolVar(&fetchFavicons, "fetch-favicons", false, "Derive source icons from site favicons when feeds have no image")
+	aggregateCmd.Flags().BoolVar(&fetchImageDimensions, "fetch-image-dimensions", false, "Record the width and height of entry images by reading their headers")
+	aggregateCmd.Flags().StringVar(&dedupScope, "dedup-scope", entry.DedupGlobal.String(), "URL dedup scope: global, or per-source to keep cross-source copies linked via _signal_also_in")
+	aggregateCmd.Flags().BoolVar(&dedupByTitle, "dedup-by-title", false, "Also deduplicate entries by normalized title and author within 48h")
+	aggregateCmd.Flags().BoolVar(&dedupBySummary, "dedup-by-summary", false, "Also deduplicate entries from one source with identical summaries")
+	aggregateCmd.Flags().BoolVar(&stripBoilerplate, "strip-boilerplate", false, "Strip text blocks repeated across most of a source's entries")
+	aggregateCmd.Flags().Float64Var(&boilerplateThreshold, "boilerplate-threshold", entry.DefaultBoilerplateThreshold, "Fraction of a source's entries that must share a block for it to be stripped")
 	aggregateCmd.Flags().BoolVar(&mergeExisting, "merge", true, "Merge with existing monthly files (preserves history)")
+	aggregateCmd.Flags().StringVar(&mergeStrategy, "merge-strategy", monthly.NewestWins.String(), "Merge strategy: newest-wins, keep-existing, or field-merge")
+	aggregateCmd.Flags().IntVar(&mergeWindowMonths, "merge-window-months", 0, "Merge with only the N most recent monthly files, leaving older ones untouched (0=all)")
+	aggregateCmd.Flags().StringVar(&fileMode, "file-mode", "0644", "Permissions for written output files (octal)")
+	aggregateCmd.Flags().StringVar(&dirMode, "dir-mode", "0755", "Permissions for created output directories (octal)")
+	aggregateCmd.Flags().BoolVar(&checkpoint, "checkpoint", false, "Checkpoint fetched feeds so an interrupted run can be resumed with --resume")
+	aggregateCmd.Flags().BoolVar(&resume, "resume", false, "Reuse feeds checkpointed by an interrupted run and fetch only the rest (implies --checkpoint)")
+	aggregateCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Directory for checkpoints (default: the user cache dir + /signal)")
+	aggregateCmd.Flags().BoolVar(&timings, "timings", false, "Print a table of feed fetch times, slowest first")
 	aggregateCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 
 	// API generation flags
 	aggregateCmd.Flags().StringVar(&apiVersion, "api-version", "", "Generate agent-friendly API (e.g., 'v1')")
-	aggregateCmd.Flags().StringVar(&planetName, "planet-name", "", "Planet name for API metadata")
-	aggregateCmd.Flags().StringVar(&planetDescription, "planet-description", "", "Planet description")
-	aggregateCmd.Flags().StringVar(&planetURL, "planet-url", "", "Planet home URL")
-	aggregateCmd.Flags().StringVar(&ownerName, "owner-name", "", "Planet owner name")
-	aggregateCmd.Flags().StringVar(&ownerURL, "owner-url", "", "Planet owner URL")
-	aggregateCmd.Flags().BoolVar(&generateAll, "generate-all", false, "Generate feeds/all.json (can be large)")
-	aggregateCmd.Flags().BoolVar(&generateSchema, "generate-schema", true, "Generate schema.json")
-	aggregateCmd.Flags().BoolVar(&generateAgentsMD, "generate-agents-md", true, "Generate AGENTS.md")
+	addAPIFlags(aggregateCmd)
+}
+
+// addAPIFlags registers the API generation flags shared by the aggregate
+// and api commands. Both commands bind the same variables with the same
+// defaults.
+func addAPIFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&planetName, "planet-name", "", "Planet name for API metadata")
+	cmd.Flags().StringVar(&planetDescription, "planet-description", "", "Planet description")
+	cmd.Flags().StringVar(&planetURL, "planet-url", "", "Planet home URL")
+	cmd.Flags().StringVar(&planetLanguage, "planet-language", "", "Planet language (e.g., en) for the planet JSON feeds, and source/tag feeds whose entries declare none")
+	cmd.Flags().StringVar(&planetIcon, "planet-icon", "", "Planet icon URL (large, square) for the planet JSON and Atom feeds")
+	cmd.Flags().StringVar(&planetFavicon, "planet-favicon", "", "Planet favicon URL (small) for the planet JSON and Atom feeds")
+	cmd.Flags().StringVar(&ownerName, "owner-name", "", "Planet owner name")
+	cmd.Flags().StringVar(&ownerEmail, "owner-email", "", "Planet owner email for the Atom feed authors")
+	cmd.Flags().StringVar(&ownerURL, "owner-url", "", "Planet owner URL")
+	cmd.Flags().StringVar(&ownerAvatar, "owner-avatar", "", "Planet owner avatar image URL")
+	cmd.Flags().BoolVar(&generateAll, "generate-all", false, "Generate feeds/all.json (can be large)")
+	cmd.Flags().BoolVar(&generateSchema, "generate-schema", true, "Generate schema.json")
+	cmd.Flags().BoolVar(&generateAgentsMD, "generate-agents-md", true, "Generate AGENTS.md")
+	cmd.Flags().IntVar(&minTagCount, "min-tag-count", 0, "Minimum entries for a tag to get a by-tag page")
+	cmd.Flags().BoolVar(&preserveMeta, "preserve-meta", false, "Merge into an existing meta/about.json, keeping hand-added keys")
+	cmd.Flags().BoolVar(&omitGenerated, "omit-generated", false, "Leave the _signal_generated timestamp out of JSON Feed files")
+	cmd.Flags().BoolVar(&skipUnchanged, "skip-unchanged", false, "Don't rewrite API files whose content is unchanged")
+	cmd.Flags().BoolVar(&tagFeeds, "tag-feeds", false, "Also write Atom/RSS feeds per tag (by-tag/{slug}.atom.xml, .rss.xml)")
+	cmd.Flags().StringSliceVar(&tagFeedFormats, "tag-feed-formats", []string{"atom", "rss"}, "Tag feed formats: atom, rss")
+	cmd.Flags().StringSliceVar(&sourceFeeds, "source-feeds", nil, "Also write per-source feeds in these formats (by-source/{slug}.atom.xml, .rss.xml): atom, rss")
+	cmd.Flags().BoolVar(&nestByYear, "nest-by-year", false, "Nest by-month files by year (by-month/2026/02.json)")
+	cmd.Flags().StringVar(&tagHierarchy, "tag-hierarchy", "", "JSON file mapping child tags to parent tags; parents' by-tag pages include descendants")
+	cmd.Flags().IntVar(&recentDays, "recent-days", 0, "Also write feeds/recent.json with the last N days of entries, for frequent pollers (0=don't)")
+	cmd.Flags().IntVar(&topTagsLimit, "top-tags", api.DefaultTopTagsLimit, "Number of top tags in stats.json")
+	cmd.Flags().IntVar(&staleDays, "stale-days", api.DefaultStaleDays, "Days without a new entry after which stats.json lists a source as stale")
+	cmd.Flags().IntVar(&writeConcurrency, "write-concurrency", api.DefaultWriteConcurrency, "Number of by-month, by-source, and by-tag files written at once (1=sequential, in a stable order)")
+}
+
+// readPriorityFiles reads the priority files named by patterns, expanding
+// globs, resolves their relative dates against now, and merges them with
+// priority.Merge.
+func readPriorityFiles(patterns []string, now time.Time) (*priority.Links, error) {
+	readPriority := priority.ReadFile
+	if expandEnv {
+		readPriority = priority.ReadFileExpand
+	}
+	var lists []*priority.Links
+	for _, pattern := range patterns {
+		files := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority pattern %q: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no priority files match %q", pattern)
+			}
+			files = matches
+		}
+		for _, file := range files {
+			if verbose {
+				fmt.Printf("Reading priority links from %s\n", file)
+			}
+			links, err := readPriority(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read priority file %s: %w", file, err)
+			}
+			links.ResolveDates(now)
+			lists = append(lists, links)
+		}
+	}
+	return priority.Merge(lists...), nil
+}
+
+// newAPIConfig builds an api.Config from the shared API flags, reading the
+// tag hierarchy file if one is set. The planet name defaults to the feed
+// title.
+func newAPIConfig(version, dir string, generatedAt time.Time, fMode, dMode os.FileMode) (api.Config, error) {
+	pName := planetName
+	if pName == "" {
+		pName = feedTitle
+	}
+	var tagParents map[string]string
+	if tagHierarchy != "" {
+		var err error
+		if tagParents, err = entry.ReadTagHierarchy(tagHierarchy); err != nil {
+			return api.Config{}, fmt.Errorf("failed to read tag hierarchy: %w", err)
+		}
+	}
+	return api.Config{
+		Version:             version,
+		OutputDir:           dir,
+		PlanetName:          pName,
+		PlanetDescription:   planetDescription,
+		PlanetURL:           planetURL,
+		DefaultLanguage:     planetLanguage,
+		IconURL:             planetIcon,
+		FaviconURL:          planetFavicon,
+		OwnerName:           ownerName,
+		OwnerEmail:          ownerEmail,
+		OwnerURL:            ownerURL,
+		OwnerAvatar:         ownerAvatar,
+		GenerateAll:         generateAll,
+		GenerateSchema:      generateSchema,
+		GenerateAgentsMD:    generateAgentsMD,
+		LatestMonths:        latestMonths,
+		MaxTotal:            maxTotal,
+		RecentDays:          recentDays,
+		MinTagCount:         minTagCount,
+		TopTagsLimit:        topTagsLimit,
+		StaleDays:           staleDays,
+		SkipUnchanged:       skipUnchanged,
+		NestByYear:          nestByYear,
+		PreserveMeta:        preserveMeta,
+		OmitGenerated:       omitGenerated,
+		GenerateTagFeeds:    tagFeeds,
+		TagFeedFormats:      tagFeedFormats,
+		GenerateSourceFeeds: sourceFeeds,
+		GeneratedAt:         generatedAt,
+		FileMode:            fMode,
+		DirMode:             dMode,
+		WriteConcurrency:    writeConcurrency,
+		TagParents:          tagParents,
+	}, nil
+}
+
+// enrichHackerNews attaches Hacker News discussions to the entries of feed
+// whose IDs are in ids, newest first and up to --enrich-hn-max lookups.
+// Failed lookups are reported as a warning; the run goes on without them.
+func enrichHackerNews(ctx context.Context, feed *entry.Feed, ids map[string]bool) {
+	var candidates []entry.Entry
+	var at []int // index in feed.Entries of each candidate
+	for i, e := range feed.Entries {
+		if ids[e.ID] && !discussions.HasHackerNews(e) {
+			candidates = append(candidates, e)
+			at = append(at, i)
+		}
+	}
+	hn := &discussions.HackerNews{UserAgent: userAgent, MaxLookups: enrichHNMax}
+	if err := hn.Fetch(ctx, candidates); err != nil && !quiet {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	found := 0
+	for j, e := range candidates {
+		if discussions.HasHackerNews(e) {
+			feed.Entries[at[j]].Discussions = e.Discussions
+			found++
+		}
+	}
+	if verbose {
+		fmt.Printf("Found Hacker News discussions for %d of %d entries without one\n", found, len(candidates))
+	}
+}
+
+// withoutMonths returns a copy of f without the entries dated in months
+// ("YYYY-MM").
+func withoutMonths(f *entry.Feed, months []string) *entry.Feed {
+	skip := make(map[string]bool, len(months))
+	for _, month := range months {
+		skip[month] = true
+	}
+	out := *f
+	out.Entries = nil
+	for _, e := range f.Entries {
+		if !skip[monthly.MonthKey(e.Date)] {
+			out.Entries = append(out.Entries, e)
+		}
+	}
+	return &out
 }
 
 func runAggregate(cmd *cobra.Command, args []string) error {
+	if err := applyConfigFile(cmd, configFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	strategy, err := monthly.ParseMergeStrategy(mergeStrategy)
+	if err != nil {
+		return err
+	}
+	switch sortOrder {
+	case "desc":
+	case "asc":
+		if priorityPinned {
+			return fmt.Errorf("--sort asc can't be combined with --priority-pinned")
+		}
+	default:
+		return fmt.Errorf("invalid --sort %q: want asc or desc", sortOrder)
+	}
+	if err := monthly.ValidateTemplate(monthlyTemplate); err != nil {
+		return err
+	}
+	scope, err := entry.ParseDedupScope(dedupScope)
+	if err != nil {
+		return err
+	}
+	generatedAt, err := sourceDateEpoch()
+	if err != nil {
+		return err
+	}
+	fMode, err := parseFileMode("file-mode", fileMode)
+	if err != nil {
+		return err
+	}
+	dMode, err := parseFileMode("dir-mode", dirMode)
+	if err != nil {
+		return err
+	}
+	if outputStdout {
+		if monthlyOutput || apiVersion != "" {
+			return fmt.Errorf("--output-stdout can't be combined with --monthly or --api-version")
+		}
+		// Keep stdout clean JSON; errors and warnings still go to stderr
+		verbose, timings, quiet = false, false, true
+		if !cmd.Flags().Changed("run-summary") {
+			runSummary = ""
+		}
+	}
+
 	// Read OPML
 	if verbose {
 		fmt.Printf("Reading OPML from %s\n", opmlFile)
 	}
-	o, err := opml.ReadFile(opmlFile)
+	readOPML := opml.ReadFile
+	if expandEnv {
+		readOPML = opml.ReadFileExpand
+	}
+	o, err := readOPML(opmlFile)
 	if err != nil {
 		return fmt.Errorf("failed to read OPML: %w", err)
 	}
+	o.MaxDepth = opmlMaxDepth
+	if err := o.CheckDepth(); err != nil {
+		return fmt.Errorf("invalid OPML %s: %w", opmlFile, err)
+	}
 
-	feeds := o.FlattenFeeds()
+	feeds := o.FlattenUniqueFeeds()
+	if includeDisabled {
+		feeds = opml.UniqueFeeds(o.FlattenAllFeeds())
+	}
 	if verbose {
 		fmt.Printf("Found %d feeds\n", len(feeds))
 	}
+	if feedFilter != "" || maxFeeds > 0 {
+		feeds = selectFeeds(feeds, feedFilter, maxFeeds)
+		if verbose {
+			fmt.Printf("Selected %d feeds:\n", len(feeds))
+			for _, f := range feeds {
+				fmt.Printf("  - %s (%s)\n", f.Title, f.XMLURL)
+			}
+		}
+	}
 
 	// Configure aggregator
 	cfg := aggregator.Config{
-		UserAgent:   "Signal/1.0 (+https://github.com/grokify/signal)",
-		Timeout:     30 * time.Second,
-		MaxEntries:  maxEntries,
-		Concurrency: concurrency,
-		FilterTags:  filterTags,
+		UserAgent:             userAgent,
+		Timeout:               fetchTimeout,
+		ConnectTimeout:        connectTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+		MaxEntries:            maxEntries,
+		MaxContentBytes:       maxContentBytes,
+		CleanContent:          cleanContent,
+		PreferGUID:            preferGUID,
+		Concurrency:           concurrency,
+		FilterTags:            filterTags,
+		DedupScope:            scope,
+		DedupByTitle:          dedupByTitle,
+		DedupBySummary:        dedupBySummary,
+		RespectRobots:         respectRobots,
+		OPMLCategoriesAsTags:  opmlCategoriesAsTags,
+		PreferOPMLTitle:       preferOPMLTitle,
+		AuthorFromFeedTitle:   authorFromFeedTitle,
+		CrawlDelay:            crawlDelay,
+		FetchFavicons:         fetchFavicons,
+		FetchImageDimensions:  fetchImageDimensions,
+		FetchMetaSummary:      fetchMetaSummary,
+		PreferCanonicalURL:    preferCanonical,
+		AbsolutizeLinks:       absolutizeLinks,
+		IncludeRaw:            includeRaw,
+		GeneratedAt:           generatedAt,
 	}
 	if maxAgeDays > 0 {
 		cfg.MaxAge = time.Duration(maxAgeDays) * 24 * time.Hour
 	}
+	if minDate != "" {
+		if cfg.MinDate, err = time.Parse("2006-01-02", minDate); err != nil {
+			return fmt.Errorf("invalid --min-date %q: want YYYY-MM-DD", minDate)
+		}
+	}
+	if cfg.FutureDates, err = aggregator.ParseFutureDatePolicy(futureDates); err != nil {
+		return err
+	}
+	cfg.FutureSkew = futureSkew
+
+	if checkpoint || resume {
+		cpPath, err := checkpointPath(cacheDir, opmlFile, outputDir)
+		if err != nil {
+			return err
+		}
+		if cfg.Checkpoint, err = aggregator.OpenCheckpoint(cpPath, resume); err != nil {
+			return fmt.Errorf("failed to open checkpoint: %w", err)
+		}
+		if verbose && cfg.Checkpoint.Len() > 0 {
+			fmt.Printf("Resuming: %d feeds already fetched (checkpoint %s)\n", cfg.Checkpoint.Len(), cpPath)
+		}
+	}
 
 	// Fetch feeds
 	agg := aggregator.New(cfg)
 	ctx := context.Background()
+	startedAt := time.Now()
 
-	var feed *entry.Feed
-	var fetchErrors []error
+	var results []aggregator.FetchResult
 
 	if verbose {
 		fmt.Println("Fetching feeds...")
@@ -153,17 +593,31 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 			WithBarWidth(30).
 			WithTextWidth(40)
 
-		var allErrors []error
-		feed, allErrors = agg.FetchAllWithProgress(ctx, o, func(current, total int, name string, entries int, err error) {
+		results = agg.FetchResults(ctx, feeds, func(current, total int, name string, entries int, err error) {
 			if err != nil {
 				renderer.Update(current, total, fmt.Sprintf("%s (error)", name))
 			} else {
 				renderer.Update(current, total, fmt.Sprintf("%s (%d entries)", name, entries))
 			}
 		})
-		fetchErrors = allErrors
 		renderer.Done("")
+	} else {
+		results = agg.FetchResults(ctx, feeds, nil)
+	}
+	if cfg.Checkpoint != nil {
+		// Every result is saved; a failed save only costs refetches on resume
+		if err := cfg.Checkpoint.Close(); err != nil && !quiet {
+			fmt.Fprintf(os.Stderr, "Warning: checkpoint incomplete, --resume may refetch feeds: %v\n", err)
+		}
+	}
 
+	feed, fetchErrors, dupes := agg.CombineWithReport(o.Title, results)
+	fetched := 0
+	for _, r := range results {
+		fetched += len(r.Entries)
+	}
+	duplicatesRemoved := fetched - len(feed.Entries)
+	if verbose {
 		fmt.Printf("Fetched %d entries from %d feeds\n", len(feed.Entries), len(feeds))
 		if len(fetchErrors) > 0 {
 			fmt.Printf("Encountered %d errors:\n", len(fetchErrors))
@@ -171,41 +625,86 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 				fmt.Printf("  - %v\n", e)
 			}
 		}
-	} else {
-		feed, fetchErrors = agg.FetchAll(ctx, o)
+		bogus := 0
+		for _, r := range results {
+			bogus += r.BogusDates
+		}
+		if bogus > 0 {
+			fmt.Printf("Dropped %d entries dated before %s\n", bogus, minDate)
+		}
+		future := 0
+		for _, r := range results {
+			future += r.FutureDates
+		}
+		if future > 0 {
+			verb := "Dropped"
+			if cfg.FutureDates == aggregator.FutureClamp {
+				verb = "Clamped"
+			}
+			fmt.Printf("%s %d entries dated more than %s in the future\n", verb, future, futureSkew)
+		}
+		if !timings {
+			fmt.Println("Slowest feeds:")
+			printTimings(aggregator.SlowestFirst(results), slowestFeedsShown)
+		}
 	}
 	feed.Title = feedTitle
-	_ = fetchErrors // errors already printed in verbose mode
+
+	if stripBoilerplate {
+		feed.StripBoilerplate(boilerplateThreshold)
+	}
 
 	// Add priority links
-	if priorityFile != "" {
-		if verbose {
-			fmt.Printf("Reading priority links from %s\n", priorityFile)
-		}
-		pLinks, err := priority.ReadFile(priorityFile)
+	if len(priorityFiles) > 0 {
+		// Relative dates resolve against the run's (possibly fixed) time
+		pLinks, err := readPriorityFiles(priorityFiles, feed.Generated)
 		if err != nil {
-			return fmt.Errorf("failed to read priority file: %w", err)
-		}
-		for _, e := range pLinks.ToEntries() {
-			feed.AddEntry(e)
+			return err
 		}
+		matched := pLinks.ApplyOverrides(feed)
 		if verbose {
-			fmt.Printf("Added %d priority links\n", len(pLinks.Links))
+			fmt.Printf("Added %d priority links (%d enriched fetched entries)\n", len(pLinks.Links), matched)
 		}
 	}
 
 	// Always deduplicate and sort
-	feed.Deduplicate()
+	beforeDedup := len(feed.Entries)
+	feed.DeduplicateScoped(scope)
+	if dedupByTitle {
+		feed.DeduplicateByTitle(entry.DefaultTitleDedupWindow)
+	}
+	if dedupBySummary {
+		feed.DedupeBySummary()
+	}
 	feed.SortByDate()
+	duplicatesRemoved += beforeDedup - len(feed.Entries)
+	if verbose {
+		fmt.Printf("Removed %d duplicates\n", duplicatesRemoved)
+	}
+	mergeDuplicatesRemoved := 0
+
+	// IDs of this run's entries, before the merge adds stored ones
+	fetchedIDs := make(map[string]bool, len(feed.Entries))
+	for _, e := range feed.Entries {
+		fetchedIDs[e.ID] = true
+	}
 
-	// Create output directory
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output dir: %w", err)
+	// Create output directory, unless only stdout is written
+	if !outputStdout || ndjsonFile != "" || atomFile != "" || dedupReport != "" || runSummary != "" {
+		if err := os.MkdirAll(outputDir, dMode); err != nil {
+			return fmt.Errorf("failed to create output dir: %w", err)
+		}
 	}
 
 	// Merge with existing entries if enabled
+	var frozenMonths []string // Monthly files outside the merge window, left untouched
 	if mergeExisting && monthlyOutput {
-		existing, err := monthly.LoadExistingEntries(outputDir, monthlyPrefix)
+		if mergeWindowMonths > 0 {
+			if months, err := monthly.ExistingMonths(outputDir, monthlyPrefix, monthlyTemplate); err == nil && len(months) > mergeWindowMonths {
+				frozenMonths = months[:len(months)-mergeWindowMonths]
+			}
+		}
+		existing, err := monthly.LoadExistingEntriesWithTemplate(outputDir, monthlyPrefix, monthlyTemplate, mergeWindowMonths)
 		if err != nil {
 			if verbose {
 				fmt.Printf("Warning: could not load existing entries: %v\n", err)
@@ -214,20 +713,69 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 			if verbose {
 				fmt.Printf("Loaded %d existing entries from monthly files\n", len(existing))
 			}
-			merged := monthly.MergeEntries(existing, feed.Entries)
+			combined := len(existing) + len(feed.Entries)
+			merged := monthly.MergeEntriesScoped(existing, feed.Entries, strategy, scope)
 			feed.Entries = merged
-			feed.Deduplicate()
+			feed.DeduplicateScoped(scope)
+			if dedupByTitle {
+				feed.DeduplicateByTitle(entry.DefaultTitleDedupWindow)
+			}
+			if dedupBySummary {
+				feed.DedupeBySummary()
+			}
 			feed.SortByDate()
+			mergeDuplicatesRemoved = combined - len(feed.Entries)
 			if verbose {
-				fmt.Printf("After merge: %d total entries\n", len(feed.Entries))
+				fmt.Printf("After merge: %d total entries (removed %d duplicates)\n", len(feed.Entries), mergeDuplicatesRemoved)
+			}
+		}
+
+		// Fetched entries for months outside the window would overwrite
+		// their unloaded files, so leave those months as they are on disk
+		if len(frozenMonths) > 0 {
+			kept := withoutMonths(feed, frozenMonths).Entries
+			if verbose && len(kept) < len(feed.Entries) {
+				fmt.Printf("Skipped %d entries in months outside the merge window\n", len(feed.Entries)-len(kept))
+			}
+			feed.Entries = kept
+
+			// Outputs covering the whole archive still need the older
+			// months' entries; their files are only read, not rewritten
+			if apiVersion != "" || ndjsonFile != "" || atomFile != "" {
+				archived := monthly.LoadMonths(outputDir, monthlyPrefix, monthlyTemplate, frozenMonths)
+				if verbose {
+					fmt.Printf("Loaded %d entries from months outside the merge window\n", len(archived))
+				}
+				feed.Entries = append(feed.Entries, archived...)
+				feed.SortByDate()
 			}
 		}
 	}
 
+	// Look up Hacker News discussions of this run's entries, skipping those
+	// the stored copies already link
+	if enrichHN {
+		enrichHackerNews(ctx, feed, fetchedIDs)
+	}
+
+	// One casing per tag across every file written below
+	feed.NormalizeTagCase()
+
 	// Write output
 	if monthlyOutput {
-		// Write monthly files
-		files, err := monthly.WriteMonthlyFiles(feed, outputDir, monthlyPrefix)
+		// Write monthly files, except those outside the merge window
+		monthlyFeed := feed
+		if len(frozenMonths) > 0 {
+			monthlyFeed = withoutMonths(feed, frozenMonths)
+		}
+		files, err := monthly.WriteMonthlyFilesWithOptions(monthlyFeed, outputDir, monthlyPrefix, monthly.WriteOptions{
+			FileMode:          fMode,
+			DirMode:           dMode,
+			ExpireAfterMonths: expireAfterMonths,
+			OmitGenerated:     omitGenerated,
+			Template:          monthlyTemplate,
+			Language:          planetLanguage,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to write monthly files: %w", err)
 		}
@@ -236,10 +784,14 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 		}
 
 		// Write index
-		index := monthly.GenerateIndex(feed, monthlyPrefix)
+		index := monthly.GenerateIndexWithTemplate(monthlyFeed, monthlyPrefix, monthlyTemplate)
 		indexPath := filepath.Join(outputDir, "index.json")
+		if len(frozenMonths) > 0 {
+			prev, _ := monthly.ReadIndex(indexPath)
+			index.AddFiles(monthly.FileRefs(outputDir, monthlyPrefix, monthlyTemplate, frozenMonths, prev))
+		}
 		indexData, _ := json.MarshalIndent(index, "", "  ")
-		if err := os.WriteFile(indexPath, indexData, 0644); err != nil {
+		if err := fileutil.WriteFile(indexPath, indexData, fMode); err != nil {
 			return fmt.Errorf("failed to write index: %w", err)
 		}
 		if verbose {
@@ -249,8 +801,15 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 		// Write latest feed in JSON Feed format
 		if latestMonths > 0 {
 			latestFeed := monthly.LatestMonths(feed, latestMonths)
+			latestFeed.Truncate(maxTotal)
+			if priorityPinned {
+				latestFeed.SortPriorityFirst()
+			}
+			if sortOrder == "asc" {
+				latestFeed.SortByDateAsc()
+			}
 			latestPath := filepath.Join(outputDir, outputFile)
-			if err := latestFeed.WriteJSONFeed(latestPath); err != nil {
+			if err := toJSONFeed(latestFeed).WriteFileMode(latestPath, fMode); err != nil {
 				return fmt.Errorf("failed to write latest feed: %w", err)
 			}
 			if verbose {
@@ -259,24 +818,61 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// Write single file in JSON Feed format
+		outputFeed := *feed
+		outputFeed.Entries = append([]entry.Entry(nil), feed.Entries...)
+		outputFeed.Truncate(maxTotal)
+		if priorityPinned {
+			outputFeed.SortPriorityFirst()
+		}
+		if sortOrder == "asc" {
+			outputFeed.SortByDateAsc()
+		}
 		outputPath := filepath.Join(outputDir, outputFile)
-		if err := feed.WriteJSONFeed(outputPath); err != nil {
+		if outputStdout {
+			data, err := toJSONFeed(&outputFeed).ToJSON()
+			if err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+			if _, err := os.Stdout.Write(append(data, '\n')); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		} else if err := toJSONFeed(&outputFeed).WriteFileMode(outputPath, fMode); err != nil {
 			return fmt.Errorf("failed to write output: %w", err)
 		}
 		if verbose {
-			fmt.Printf("Wrote %d entries to %s\n", len(feed.Entries), outputPath)
+			fmt.Printf("Wrote %d entries to %s\n", len(outputFeed.Entries), outputPath)
 		}
 	}
 
-	// Generate Atom feed
-	if atomFile != "" {
-		atomFeed := atom.FromFeed(feed, feedURL)
-		atomPath := filepath.Join(outputDir, atomFile)
-		if err := atomFeed.WriteFile(atomPath); err != nil {
-			return fmt.Errorf("failed to write Atom feed: %w", err)
+	// Write newline-delimited entries for streaming ingestion
+	if ndjsonFile != "" {
+		ndjsonPath := filepath.Join(outputDir, ndjsonFile)
+		if err := feed.WriteNDJSONMode(ndjsonPath, fMode); err != nil {
+			return fmt.Errorf("failed to write NDJSON: %w", err)
 		}
 		if verbose {
-			fmt.Printf("Wrote Atom feed to %s\n", atomPath)
+			fmt.Printf("Wrote %d entries to %s\n", len(feed.Entries), ndjsonPath)
+		}
+	}
+
+	// Generate Atom feed, paged per RFC 5005 when a page size is set
+	if atomFile != "" {
+		pages := atom.PageCount(len(feed.Entries), atomPageSize)
+		for page := 1; page <= pages; page++ {
+			atomFeed := atom.FromFeedPagedWithOptions(feed, feedURL, page, atomPageSize, atom.Options{
+				AuthorName:  ownerName,
+				AuthorEmail: ownerEmail,
+				AuthorURI:   ownerURL,
+				IconURL:     planetFavicon,
+				LogoURL:     planetIcon,
+			})
+			atomPath := filepath.Join(outputDir, atom.PageURL(atomFile, page))
+			if err := atomFeed.WriteFileMode(atomPath, fMode); err != nil {
+				return fmt.Errorf("failed to write Atom feed: %w", err)
+			}
+			if verbose {
+				fmt.Printf("Wrote Atom feed to %s\n", atomPath)
+			}
 		}
 	}
 
@@ -286,12 +882,6 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 			fmt.Printf("Generating API %s structure...\n", apiVersion)
 		}
 
-		// Use feed title as planet name if not specified
-		pName := planetName
-		if pName == "" {
-			pName = feedTitle
-		}
-
 		// Convert OPML feeds to SourceInfo
 		var sources []api.SourceInfo
 		for _, f := range feeds {
@@ -304,32 +894,175 @@ func runAggregate(cmd *cobra.Command, args []string) error {
 			})
 		}
 
-		cfg := api.Config{
-			Version:           apiVersion,
-			OutputDir:         outputDir,
-			PlanetName:        pName,
-			PlanetDescription: planetDescription,
-			PlanetURL:         planetURL,
-			OwnerName:         ownerName,
-			OwnerURL:          ownerURL,
-			GenerateAll:       generateAll,
-			GenerateSchema:    generateSchema,
-			GenerateAgentsMD:  generateAgentsMD,
-			LatestMonths:      latestMonths,
+		cfg, err := newAPIConfig(apiVersion, outputDir, generatedAt, fMode, dMode)
+		if err != nil {
+			return err
 		}
-
-		if err := api.Generate(feed, sources, cfg); err != nil {
+		report, err := api.GenerateWithReport(feed, sources, cfg)
+		if err != nil {
 			return fmt.Errorf("failed to generate API: %w", err)
 		}
 		if verbose {
-			fmt.Printf("Generated API %s structure in %s\n", apiVersion, outputDir)
+			fmt.Printf("Generated API %s structure in %s (%d files written, %d unchanged)\n",
+				apiVersion, outputDir, report.Written, report.Skipped)
+		}
+	}
+
+	// Write duplicate report for auditing source overlap
+	if dedupReport != "" {
+		if dupes == nil {
+			dupes = []entry.DuplicateGroup{}
+		}
+		reportData, _ := json.MarshalIndent(dupes, "", "  ")
+		reportPath := filepath.Join(outputDir, dedupReport)
+		if err := fileutil.WriteFile(reportPath, reportData, fMode); err != nil {
+			return fmt.Errorf("failed to write dedup report: %w", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote %d duplicate groups to %s\n", len(dupes), reportPath)
 		}
 	}
 
-	fmt.Printf("Generated feed with %d entries\n", len(feed.Entries))
+	// Write OPML of working feeds for pruning the feed list
+	if exportOPML != "" {
+		exported := aggregator.ExportOPML(feeds, results, aggregator.ExportOptions{
+			ResolveMeta:   exportOPMLResolve,
+			DisableFailed: exportOPMLDisable,
+		})
+		exported.Title = o.Title
+		exported.OwnerName = o.OwnerName
+		exported.OwnerEmail = o.OwnerEmail
+		exported.DateCreated = o.DateCreated
+		exported.DateModified = time.Now().UTC()
+		if !generatedAt.IsZero() {
+			exported.DateModified = generatedAt
+		}
+		if isJSONPath(exportOPML) {
+			err = exported.WriteFileMode(exportOPML, fMode)
+		} else {
+			err = exported.WriteXMLFileMode(exportOPML, fMode)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export OPML: %w", err)
+		}
+		if verbose {
+			fmt.Printf("Exported %d feeds to %s\n", len(exported.Outlines), exportOPML)
+		}
+	}
+
+	// Write run summary for monitoring
+	if runSummary != "" {
+		summary := aggregator.NewRunSummary(startedAt, results, len(feed.Entries))
+		summary.DuplicatesRemoved = duplicatesRemoved
+		summary.MergeDuplicatesRemoved = mergeDuplicatesRemoved
+		summaryPath := filepath.Join(outputDir, runSummary)
+		if err := summary.WriteFileMode(summaryPath, fMode); err != nil {
+			return fmt.Errorf("failed to write run summary: %w", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote run summary to %s\n", summaryPath)
+		}
+	}
+
+	// The run completed, so the next one starts fresh
+	if cfg.Checkpoint != nil {
+		if err := cfg.Checkpoint.Remove(); err != nil && !quiet {
+			fmt.Fprintf(os.Stderr, "Warning: could not remove checkpoint: %v\n", err)
+		}
+	}
+
+	if timings {
+		printTimings(aggregator.SlowestFirst(results), 0)
+	}
+	if !quiet {
+		fmt.Printf("Generated feed with %d entries\n", len(feed.Entries))
+	}
+	if len(fetchErrors) > 0 {
+		// Output was written; report the failures without usage text
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &partialFailureError{errs: fetchErrors, total: len(feeds)}
+	}
 	return nil
 }
 
+// checkpointPath returns the checkpoint file for aggregating opmlFile into
+// outputDir, under dir or the user cache dir, creating the directory.
+func checkpointPath(dir, opmlFile, outputDir string) (string, error) {
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("no cache dir (use --cache-dir): %w", err)
+		}
+		dir = filepath.Join(userDir, "signal")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	absOPML, _ := filepath.Abs(opmlFile)
+	absOut, _ := filepath.Abs(outputDir)
+	sum := sha256.Sum256([]byte(absOPML + "\x00" + absOut))
+	return filepath.Join(dir, "checkpoint-"+hex.EncodeToString(sum[:8])+".ndjson"), nil
+}
+
+// toJSONFeed converts f to a top-level planet JSON Feed, applying the
+// planet icons, --planet-language, and --omit-generated.
+func toJSONFeed(f *entry.Feed) *jsonfeed.Feed {
+	jf := f.ToJSONFeed()
+	jf.Icon = planetIcon
+	jf.Favicon = planetFavicon
+	jf.Language = planetLanguage
+	if omitGenerated {
+		jf.SignalGenerated = ""
+	}
+	return jf
+}
+
+// slowestFeedsShown is how many feeds verbose output lists by fetch time.
+const slowestFeedsShown = 5
+
+// printTimings prints a table of fetch durations for results, already
+// sorted, limited to the first n (0 = all).
+func printTimings(results []aggregator.FetchResult, n int) {
+	if n > 0 && len(results) > n {
+		results = results[:n]
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DURATION\tENTRIES\tSTATUS\tFORMAT\tFEED")
+	for _, r := range results {
+		status, format := "ok", r.Format
+		if r.Error != nil {
+			status = "error"
+		} else if r.Resumed {
+			status = "resumed"
+		}
+		if format == "" {
+			format = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", r.Duration.Round(time.Millisecond), len(r.Entries), status, format, r.Outline.XMLURL)
+	}
+	tw.Flush()
+}
+
+// selectFeeds narrows feeds to those whose title, text, or URL contains
+// filter (case-insensitive), then caps the result at limit (0 = no cap).
+func selectFeeds(feeds []opml.Outline, filter string, limit int) []opml.Outline {
+	filter = strings.ToLower(filter)
+	var selected []opml.Outline
+	for _, f := range feeds {
+		if limit > 0 && len(selected) >= limit {
+			break
+		}
+		if filter == "" ||
+			strings.Contains(strings.ToLower(f.Title), filter) ||
+			strings.Contains(strings.ToLower(f.Text), filter) ||
+			strings.Contains(strings.ToLower(f.XMLURL), filter) {
+			selected = append(selected, f)
+		}
+	}
+	return selected
+}
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new Signal project",
@@ -374,7 +1107,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if err := sampleOPML.WriteFile("feeds.json"); err != nil {
 		return fmt.Errorf("failed to write feeds.json: %w", err)
 	}
-	fmt.Println("Created feeds.json")
+	if !quiet {
+		fmt.Println("Created feeds.json")
+	}
 
 	// Create sample priority links
 	samplePriority := &priority.Links{
@@ -397,15 +1132,45 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if err := samplePriority.WriteFile("priority.json"); err != nil {
 		return fmt.Errorf("failed to write priority.json: %w", err)
 	}
-	fmt.Println("Created priority.json")
+	if !quiet {
+		fmt.Println("Created priority.json")
+	}
 
 	// Create data directory
 	if err := os.MkdirAll("data", 0755); err != nil {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
-	fmt.Println("Created data/ directory")
+	if !quiet {
+		fmt.Println("Created data/ directory")
+	}
 
-	fmt.Println("\nSignal project initialized!")
-	fmt.Println("Run 'signal aggregate' to fetch feeds and generate JSON output.")
+	if !quiet {
+		fmt.Println("\nSignal project initialized!")
+		fmt.Println("Run 'signal aggregate' to fetch feeds and generate JSON output.")
+	}
 	return nil
 }
+
+// sourceDateEpoch returns the generation time from the SOURCE_DATE_EPOCH
+// environment variable (Unix seconds), or the zero time when it is unset.
+// See https://reproducible-builds.org/specs/source-date-epoch/.
+func sourceDateEpoch() (time.Time, error) {
+	v := os.Getenv("SOURCE_DATE_EPOCH")
+	if v == "" {
+		return time.Time{}, nil
+	}
+	secs, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", v, err)
+	}
+	return time.Unix(secs, 0).UTC(), nil
+}
+
+// parseFileMode parses an octal permission string such as "0640".
+func parseFileMode(flag, s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid --%s %q: must be octal permissions like 0644", flag, s)
+	}
+	return os.FileMode(mode), nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/grokify/signal/priority"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check a priority links file for curation mistakes",
+	Long: `Check a hand-edited priority links file for missing URLs or titles,
+duplicate URLs, and duplicate or out-of-order ranks, printing each problem.
+Exits non-zero if any are found, so it can run before aggregating.`,
+	RunE: runValidate,
+}
+
+var validatePriority string
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringVarP(&validatePriority, "priority", "p", "priority.json", "Priority links file (JSON)")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	links, err := priority.ReadFile(validatePriority)
+	if err != nil {
+		return fmt.Errorf("failed to read priority file: %w", err)
+	}
+	errs := links.Validate()
+	for _, err := range errs {
+		fmt.Printf("%s: %v\n", validatePriority, err)
+	}
+	if len(errs) > 0 {
+		// The problems were listed; report the count without usage text
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s: %d problems found", validatePriority, len(errs))
+	}
+	if !quiet {
+		fmt.Printf("%s: %d links OK\n", validatePriority, len(links.Links))
+	}
+	return nil
+}
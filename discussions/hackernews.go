@@ -0,0 +1,194 @@
+// Package discussions enriches entries with links to where they are
+// discussed, such as Hacker News.
+package discussions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grokify/signal/entry"
+)
+
+// DefaultHackerNewsAPI is the Algolia search endpoint for Hacker News.
+const DefaultHackerNewsAPI = "https://hn.algolia.com/api/v1/search"
+
+// DefaultHackerNewsDelay spaces requests to stay well under the Algolia
+// API's limit of 10,000 requests per hour.
+const DefaultHackerNewsDelay = 400 * time.Millisecond
+
+// DefaultHackerNewsMaxLookups caps the lookups of one Fetch, so a large
+// planet can't make a run take long or spend much of the API's limit.
+const DefaultHackerNewsMaxLookups = 100
+
+// hackerNewsItemURL is the discussion page of a Hacker News story.
+const hackerNewsItemURL = "https://news.ycombinator.com/item?id="
+
+// maxConsecutiveFailures stops a run early when the API is unreachable or
+// refusing requests, rather than failing once per entry.
+const maxConsecutiveFailures = 3
+
+// HackerNews looks up Hacker News stories submitting entries' URLs.
+type HackerNews struct {
+	// Client for API requests (nil = a client with a 10s timeout)
+	Client *http.Client
+	// BaseURL of the search API ("" = DefaultHackerNewsAPI)
+	BaseURL string
+	// UserAgent for API requests ("" = Go's default)
+	UserAgent string
+	// Delay between requests (0 = DefaultHackerNewsDelay)
+	Delay time.Duration
+	// MaxLookups caps the entries looked up per Fetch
+	// (0 = DefaultHackerNewsMaxLookups)
+	MaxLookups int
+}
+
+// FetchHackerNews attaches a "hackernews" Discussion, with the story's
+// points and comment count, to each entry whose URL has been submitted to
+// Hacker News, using the default HackerNews settings; see Fetch.
+func FetchHackerNews(entries []entry.Entry) error {
+	return (&HackerNews{}).Fetch(context.Background(), entries)
+}
+
+// Fetch attaches a "hackernews" Discussion to each entry whose URL has
+// been submitted to Hacker News. Entries that already have one are
+// skipped, and at most MaxLookups entries are looked up, in order, so
+// pass entries newest first. Lookups are made one at a time, Delay apart.
+// Lookups that fail leave their entry unchanged and are reported in the
+// returned error; after a few consecutive failures, or when the API rate
+// limits, the remaining entries are skipped.
+func (h *HackerNews) Fetch(ctx context.Context, entries []entry.Entry) error {
+	delay := h.Delay
+	if delay <= 0 {
+		delay = DefaultHackerNewsDelay
+	}
+	maxLookups := h.MaxLookups
+	if maxLookups <= 0 {
+		maxLookups = DefaultHackerNewsMaxLookups
+	}
+	var errs []error
+	failures, lookups := 0, 0
+	for i := range entries {
+		e := &entries[i]
+		if e.URL == "" || HasHackerNews(*e) {
+			continue
+		}
+		if lookups == maxLookups {
+			break
+		}
+		if lookups > 0 {
+			select {
+			case <-ctx.Done():
+				return errors.Join(append(errs, ctx.Err())...)
+			case <-time.After(delay):
+			}
+		}
+		lookups++
+		found, err := h.search(ctx, e.URL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("hacker news lookup for %s: %w", e.URL, err))
+			failures++
+			if failures >= maxConsecutiveFailures || errors.Is(err, errRateLimited) {
+				errs = append(errs, fmt.Errorf("hacker news lookups stopped after %d of %d entries", i+1, len(entries)))
+				break
+			}
+			continue
+		}
+		failures = 0
+		e.Discussions = entry.MergeDiscussions(e.Discussions, found)
+	}
+	return errors.Join(errs...)
+}
+
+// HasHackerNews reports whether e has a "hackernews" Discussion.
+func HasHackerNews(e entry.Entry) bool {
+	for _, d := range e.Discussions {
+		if d.Platform == "hackernews" {
+			return true
+		}
+	}
+	return false
+}
+
+var errRateLimited = errors.New("rate limited")
+
+// hackerNewsHit is a story in an Algolia search response.
+type hackerNewsHit struct {
+	ObjectID    string `json:"objectID"`
+	URL         string `json:"url"`
+	Points      int    `json:"points"`
+	NumComments int    `json:"num_comments"`
+}
+
+// search returns a Discussion for each story submitting articleURL.
+func (h *HackerNews) search(ctx context.Context, articleURL string) ([]entry.Discussion, error) {
+	base := h.BaseURL
+	if base == "" {
+		base = DefaultHackerNewsAPI
+	}
+	q := url.Values{}
+	q.Set("query", articleURL)
+	q.Set("restrictSearchableAttributes", "url")
+	q.Set("tags", "story")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if h.UserAgent != "" {
+		req.Header.Set("User-Agent", h.UserAgent)
+	}
+
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, errRateLimited
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var result struct {
+		Hits []hackerNewsHit `json:"hits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	var found []entry.Discussion
+	for _, hit := range result.Hits {
+		// The search matches URL words, so keep only exact submissions
+		if hit.ObjectID == "" || !sameArticle(hit.URL, articleURL) {
+			continue
+		}
+		found = append(found, entry.Discussion{
+			Platform: "hackernews",
+			URL:      hackerNewsItemURL + hit.ObjectID,
+			ID:       hit.ObjectID,
+			Score:    hit.Points,
+			Comments: hit.NumComments,
+		})
+	}
+	return found, nil
+}
+
+// sameArticle reports whether two URLs name the same article, ignoring
+// the scheme, case, and a trailing slash.
+func sameArticle(a, b string) bool {
+	normalize := func(u string) string {
+		u = strings.ToLower(strings.TrimRight(u, "/"))
+		u = strings.TrimPrefix(u, "https://")
+		return strings.TrimPrefix(u, "http://")
+	}
+	return a != "" && normalize(a) == normalize(b)
+}
@@ -0,0 +1,90 @@
+package discussions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grokify/signal/entry"
+)
+
+func TestFetchKeepsOnlySameArticle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("query"); got != "https://example.com/post" {
+			t.Errorf("query = %q", got)
+		}
+		w.Write([]byte(`{"hits": [
+			{"objectID": "1", "url": "http://Example.com/post/", "points": 50, "num_comments": 12},
+			{"objectID": "2", "url": "https://example.com/post-followup", "points": 9},
+			{"objectID": "3", "url": "https://other.example/https://example.com/post"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	entries := []entry.Entry{{URL: "https://example.com/post"}}
+	hn := &HackerNews{BaseURL: srv.URL, Delay: time.Millisecond}
+	if err := hn.Fetch(context.Background(), entries); err != nil {
+		t.Fatal(err)
+	}
+	want := []entry.Discussion{{
+		Platform: "hackernews",
+		URL:      "https://news.ycombinator.com/item?id=1",
+		ID:       "1",
+		Score:    50,
+		Comments: 12,
+	}}
+	if got := entries[0].Discussions; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("discussions = %+v, want %+v", got, want)
+	}
+}
+
+func TestFetchStopsWhenRateLimited(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	entries := []entry.Entry{{URL: "https://example.com/a"}, {URL: "https://example.com/b"}, {URL: "https://example.com/c"}}
+	hn := &HackerNews{BaseURL: srv.URL, Delay: time.Millisecond}
+	err := hn.Fetch(context.Background(), entries)
+	if err == nil || !strings.Contains(err.Error(), "stopped after 1 of 3") {
+		t.Errorf("err = %v, want lookups stopped after the first", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("made %d requests after a 429, want 1", n)
+	}
+	for _, e := range entries {
+		if len(e.Discussions) != 0 {
+			t.Errorf("%s: discussions = %+v, want none", e.URL, e.Discussions)
+		}
+	}
+}
+
+func TestFetchSkipsDiscussedEntriesAndCapsLookups(t *testing.T) {
+	var queried []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queried = append(queried, r.URL.Query().Get("query"))
+		w.Write([]byte(`{"hits": []}`))
+	}))
+	defer srv.Close()
+
+	entries := []entry.Entry{
+		{URL: "https://example.com/a", Discussions: []entry.Discussion{{Platform: "hackernews", URL: "https://news.ycombinator.com/item?id=9"}}},
+		{URL: "https://example.com/b"},
+		{URL: "https://example.com/c"},
+		{URL: "https://example.com/d"},
+	}
+	hn := &HackerNews{BaseURL: srv.URL, Delay: time.Millisecond, MaxLookups: 2}
+	if err := hn.Fetch(context.Background(), entries); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"https://example.com/b", "https://example.com/c"}; strings.Join(queried, " ") != strings.Join(want, " ") {
+		t.Errorf("queried %v, want %v", queried, want)
+	}
+}
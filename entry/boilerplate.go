@@ -0,0 +1,117 @@
+package entry
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultBoilerplateThreshold is the default fraction of a source's entries
+// that must share a block for StripBoilerplate to treat it as boilerplate.
+const DefaultBoilerplateThreshold = 0.8
+
+// minBoilerplateEntries is the fewest entries a source needs before
+// StripBoilerplate will learn boilerplate from it.
+const minBoilerplateEntries = 3
+
+// blockEnd matches the end of a block of HTML or text: a closing
+// paragraph or div, a line break, or a newline.
+var blockEnd = regexp.MustCompile(`(?i)</(p|div)\s*>|<br\s*/?>|\n`)
+
+// StripBoilerplate removes text blocks that a source repeats across most of
+// its entries, such as "The post X appeared first on Y" footers or ad
+// blocks, from Summary and Content. Blocks are paragraphs or lines,
+// compared after stripping HTML, punctuation, and case, with the entry's
+// own title masked so per-post templates match. A block is boilerplate when
+// it appears in at least threshold (0-1] of a source's entries; sources
+// with fewer than three entries are left alone.
+func (f *Feed) StripBoilerplate(threshold float64) {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultBoilerplateThreshold
+	}
+
+	bySource := make(map[string][]int)
+	for i, e := range f.Entries {
+		key := e.Feed.URL + "\x00" + e.Feed.Title
+		bySource[key] = append(bySource[key], i)
+	}
+
+	for _, idxs := range bySource {
+		if len(idxs) < minBoilerplateEntries {
+			continue
+		}
+
+		// Count the entries each normalized block appears in
+		counts := make(map[string]int)
+		for _, i := range idxs {
+			seen := make(map[string]bool)
+			e := f.Entries[i]
+			for _, s := range []string{e.Summary, e.Content} {
+				for _, block := range splitBlocks(s) {
+					if key := blockKey(block, e.Title); key != "" && !seen[key] {
+						seen[key] = true
+						counts[key]++
+					}
+				}
+			}
+		}
+
+		boilerplate := make(map[string]bool)
+		for key, n := range counts {
+			if float64(n) >= threshold*float64(len(idxs)) {
+				boilerplate[key] = true
+			}
+		}
+		if len(boilerplate) == 0 {
+			continue
+		}
+
+		for _, i := range idxs {
+			e := &f.Entries[i]
+			e.Summary = removeBlocks(e.Summary, e.Title, boilerplate)
+			e.Content = removeBlocks(e.Content, e.Title, boilerplate)
+		}
+	}
+}
+
+// splitBlocks splits s after each block end, so the blocks concatenate back to s.
+func splitBlocks(s string) []string {
+	var blocks []string
+	start := 0
+	for _, loc := range blockEnd.FindAllStringIndex(s, -1) {
+		blocks = append(blocks, s[start:loc[1]])
+		start = loc[1]
+	}
+	if start < len(s) {
+		blocks = append(blocks, s[start:])
+	}
+	return blocks
+}
+
+// blockKey returns the comparison key of a block, with title masked.
+func blockKey(block, title string) string {
+	key := normalizeText(HTMLToText(block))
+	if t := normalizeText(title); t != "" {
+		key = strings.ReplaceAll(key, t, "\x00")
+	}
+	return key
+}
+
+// removeBlocks drops the blocks of s whose keys are boilerplate.
+func removeBlocks(s, title string, boilerplate map[string]bool) string {
+	if s == "" {
+		return s
+	}
+	var b strings.Builder
+	removed := false
+	for _, block := range splitBlocks(s) {
+		if boilerplate[blockKey(block, title)] {
+			removed = true
+			continue
+		}
+		b.WriteString(block)
+	}
+	if !removed {
+		return s
+	}
+	return strings.TrimSpace(b.String())
+}
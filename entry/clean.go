@@ -0,0 +1,106 @@
+package entry
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// emptyRemovable are the elements CleanContent drops when they contain
+// nothing but whitespace.
+var emptyRemovable = map[string]bool{"div": true, "span": true, "p": true}
+
+// CleanContent removes clutter from an HTML fragment: comments, tracking
+// pixels (images whose width and height attributes are both 0 or 1), and
+// div, span, and p elements left with nothing but whitespace, including
+// those emptied by the other removals. It is deliberately conservative:
+// elements with an id, which may be link targets, are kept, and other
+// markup is copied through unchanged.
+func CleanContent(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	type frame struct {
+		name      string
+		start     int  // output offset of the start tag
+		content   int  // output offset after the start tag
+		removable bool // dropped if its content is only whitespace
+	}
+	var out []byte
+	var open []frame
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return string(out)
+		case html.CommentToken:
+			continue
+		case html.StartTagToken, html.SelfClosingTagToken:
+			raw := append([]byte(nil), z.Raw()...)
+			tok := z.Token()
+			if tok.Data == "img" && isTrackingPixel(tok) {
+				continue
+			}
+			start := len(out)
+			out = append(out, raw...)
+			if tt == html.StartTagToken && !voidElements[tok.Data] {
+				open = append(open, frame{
+					name:      tok.Data,
+					start:     start,
+					content:   len(out),
+					removable: emptyRemovable[tok.Data] && !hasAttr(tok, "id"),
+				})
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			i := len(open) - 1
+			for i >= 0 && open[i].name != string(name) {
+				i--
+			}
+			if i < 0 {
+				out = append(out, z.Raw()...) // stray end tag
+				continue
+			}
+			f := open[i]
+			open = open[:i]
+			if f.removable && len(bytes.TrimSpace(out[f.content:])) == 0 {
+				out = out[:f.start]
+				continue
+			}
+			out = append(out, z.Raw()...)
+		default:
+			out = append(out, z.Raw()...)
+		}
+	}
+}
+
+// isTrackingPixel reports whether an img token is sized 0 or 1 pixel in
+// both dimensions by its attributes.
+func isTrackingPixel(tok html.Token) bool {
+	tiny := func(v string) bool {
+		v = strings.TrimSuffix(strings.TrimSpace(v), "px")
+		return v == "0" || v == "1"
+	}
+	var width, height bool
+	for _, a := range tok.Attr {
+		switch a.Key {
+		case "width":
+			width = tiny(a.Val)
+		case "height":
+			height = tiny(a.Val)
+		}
+	}
+	return width && height
+}
+
+func hasAttr(tok html.Token, key string) bool {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
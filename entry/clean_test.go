@@ -0,0 +1,44 @@
+package entry
+
+import "testing"
+
+func TestCleanContent(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"HTML comment",
+			`<p>Hello <!-- generated by CMS -->world</p>`,
+			`<p>Hello world</p>`,
+		},
+		{
+			"tracking pixel",
+			`<p>Read on.</p><img src="https://t.example.com/p.gif" width="1" height="1">`,
+			`<p>Read on.</p>`,
+		},
+		{
+			"paragraph emptied by a tracking pixel",
+			`<p>Text</p><p><img src="https://t.example.com/p.gif" width="0" height="0" /></p>`,
+			`<p>Text</p>`,
+		},
+		{
+			"real image kept",
+			`<img src="https://example.com/photo.jpg" width="640" height="1">`,
+			`<img src="https://example.com/photo.jpg" width="640" height="1">`,
+		},
+		{
+			"empty anchor target kept",
+			`<div id="footnotes"></div>`,
+			`<div id="footnotes"></div>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanContent(tt.in); got != tt.want {
+				t.Errorf("CleanContent(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,34 +2,48 @@
 package entry
 
 import (
+	"bufio"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/grokify/signal/internal/fileutil"
 	"github.com/grokify/signal/jsonfeed"
 )
 
 // Entry represents a single feed entry in the aggregated output.
 type Entry struct {
-	ID           string       `json:"id"`
-	Title        string       `json:"title"`
-	URL          string       `json:"url"`
-	Author       string       `json:"author,omitempty"`
-	Date         time.Time    `json:"date"`
-	Feed         FeedMeta     `json:"feed"`
-	Tags         []string     `json:"tags,omitempty"`
-	Summary      string       `json:"summary,omitempty"`
-	Content      string       `json:"content,omitempty"`
-	Image        string       `json:"image,omitempty"`        // Main image URL
-	ImageAlt     string       `json:"imageAlt,omitempty"`     // Alt text for image
-	Source       *Source      `json:"source,omitempty"`       // Platform source metadata
-	IsPriority   bool         `json:"isPriority,omitempty"`   // Hand-curated priority link
-	PriorityRank int          `json:"priorityRank,omitempty"` // Ordering for priority links
-	Discussions  []Discussion `json:"discussions,omitempty"`  // Links to discussions (HN, Reddit, etc.)
+	ID               string         `json:"id"`
+	Title            string         `json:"title"`
+	URL              string         `json:"url"`
+	ExternalURL      string         `json:"externalUrl,omitempty"` // Linked article for link-blog posts, or the feed link when URL is canonical
+	Author           string         `json:"author,omitempty"`
+	Language         string         `json:"language,omitempty"` // Language tag (e.g., "en") declared by the source feed or its outline
+	Date             time.Time      `json:"date"`
+	DateClamped      bool           `json:"dateClamped,omitempty"` // Date is the fetch time, the feed having dated the entry in the future
+	Feed             FeedMeta       `json:"feed"`
+	Tags             []string       `json:"tags,omitempty"`
+	OutlineTags      []string       `json:"outlineTags,omitempty"` // Subset of Tags assigned by the OPML outline
+	Summary          string         `json:"summary,omitempty"`
+	Content          string         `json:"content,omitempty"`
+	ContentTruncated bool           `json:"contentTruncated,omitempty"` // Content was cut to the MaxContentBytes cap
+	WordCount        int            `json:"wordCount,omitempty"`        // Words in the visible text of Content, or Summary without content
+	Image            string         `json:"image,omitempty"`            // Main image URL
+	ImageAlt         string         `json:"imageAlt,omitempty"`         // Alt text for image
+	ImageMeta        *ImageMeta     `json:"imageMeta,omitempty"`        // Image dimensions, when fetched
+	Source           *Source        `json:"source,omitempty"`           // Platform source metadata
+	IsPriority       bool           `json:"isPriority,omitempty"`       // Hand-curated priority link
+	PriorityRank     int            `json:"priorityRank,omitempty"`     // Ordering for priority links
+	PriorityPeriod   string         `json:"priorityPeriod,omitempty"`   // Curation period of a priority link (e.g., "2026-02")
+	Discussions      []Discussion   `json:"discussions,omitempty"`      // Links to discussions (HN, Reddit, etc.)
+	AlsoIn           []AlsoIn       `json:"alsoIn,omitempty"`           // Same URL from other sources (per-source dedup)
+	Raw              map[string]any `json:"raw,omitempty"`              // Original feed item fields, for debugging
 }
 
 // Source represents metadata about the content source platform.
@@ -39,6 +53,13 @@ type Source struct {
 	PostID   string `json:"postId,omitempty"` // Platform-specific post ID
 }
 
+// ImageMeta records the pixel dimensions of an entry's image, so frontends
+// can reserve space for it before it loads.
+type ImageMeta struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
 // Discussion represents a link to a discussion forum.
 type Discussion struct {
 	Platform string `json:"platform"`           // "hackernews", "reddit", "lobsters", etc.
@@ -48,11 +69,21 @@ type Discussion struct {
 	Comments int    `json:"comments,omitempty"` // Comment count at time of capture
 }
 
+// AlsoIn links an entry to another source's entry for the same URL.
+type AlsoIn struct {
+	ID        string `json:"id"`
+	FeedTitle string `json:"feedTitle,omitempty"`
+	FeedURL   string `json:"feedUrl,omitempty"`
+}
+
 // FeedMeta contains metadata about the source feed.
 type FeedMeta struct {
 	Title   string `json:"title"`
 	URL     string `json:"url"`
 	IconURL string `json:"iconUrl,omitempty"`
+	// Description is the feed's own description, for sources the OPML
+	// doesn't describe
+	Description string `json:"description,omitempty"`
 }
 
 // GenerateID creates a unique ID for an entry based on URL and date.
@@ -62,6 +93,22 @@ func GenerateID(url string, date time.Time) string {
 	return hex.EncodeToString(hash[:8])
 }
 
+// GenerateGUIDID creates an ID from a feed item's GUID, scoped to the feed
+// since GUIDs are only unique within one. Unlike GenerateID it survives
+// the feed rewriting an item's URL or date.
+func GenerateGUIDID(feedURL, guid string) string {
+	hash := sha256.Sum256([]byte(feedURL + "\x00" + guid))
+	return hex.EncodeToString(hash[:8])
+}
+
+// generateLongID creates a longer ID salted with the title, used to
+// disambiguate entries whose GenerateID collides.
+func generateLongID(url string, date time.Time, title string) string {
+	data := url + date.Format(time.RFC3339) + "\x00" + title
+	hash := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(hash[:16])
+}
+
 // Feed represents the complete aggregated feed output.
 type Feed struct {
 	Generated   time.Time `json:"generated"`
@@ -90,45 +137,390 @@ func (f *Feed) AddEntry(e Entry) {
 	f.Entries = append(f.Entries, e)
 }
 
-// SortByDate sorts entries by date, newest first.
+// SortByDate sorts entries by date, newest first. Entries with the same
+// date are ordered by ID, so output is reproducible run to run.
 func (f *Feed) SortByDate() {
-	sort.Slice(f.Entries, func(i, j int) bool {
-		return f.Entries[i].Date.After(f.Entries[j].Date)
+	sort.SliceStable(f.Entries, func(i, j int) bool {
+		a, b := f.Entries[i], f.Entries[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.After(b.Date)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// SortByDateAsc sorts entries by date, oldest first, for chronological
+// consumers such as timelines. Ties are ordered by ID as in SortByDate.
+func (f *Feed) SortByDateAsc() {
+	sort.SliceStable(f.Entries, func(i, j int) bool {
+		a, b := f.Entries[i], f.Entries[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.ID < b.ID
 	})
 }
 
+// SortPriorityFirst sorts priority entries to the top, ordered by
+// PriorityRank ascending (unranked last), followed by the remaining
+// entries newest first. Ties are broken by date, newest first.
+func (f *Feed) SortPriorityFirst() {
+	sort.SliceStable(f.Entries, func(i, j int) bool {
+		a, b := f.Entries[i], f.Entries[j]
+		if a.IsPriority != b.IsPriority {
+			return a.IsPriority
+		}
+		if a.IsPriority && a.PriorityRank != b.PriorityRank {
+			if a.PriorityRank == 0 || b.PriorityRank == 0 {
+				return b.PriorityRank == 0
+			}
+			return a.PriorityRank < b.PriorityRank
+		}
+		return a.Date.After(b.Date)
+	})
+}
+
+// Limit truncates the feed to its first n entries, preserving order.
+// Call SortByDate first to keep the newest n. n <= 0 is a no-op.
+func (f *Feed) Limit(n int) {
+	if n <= 0 || n >= len(f.Entries) {
+		return
+	}
+	f.Entries = f.Entries[:n]
+}
+
+// Truncate keeps only the newest n entries, sorted newest first.
+// Unlike Limit it sorts first, so it is safe on an unsorted feed.
+// n <= 0 is a no-op.
+func (f *Feed) Truncate(n int) {
+	if n <= 0 {
+		return
+	}
+	f.SortByDate()
+	f.Limit(n)
+}
+
+// LimitByAge removes entries older than d relative to now, preserving
+// the order of the remaining entries. d <= 0 is a no-op.
+func (f *Feed) LimitByAge(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-d)
+	kept := f.Entries[:0]
+	for _, e := range f.Entries {
+		if !e.Date.Before(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	f.Entries = kept
+}
+
+// DedupScope controls which entries URL deduplication may collapse.
+type DedupScope int
+
+const (
+	// DedupGlobal collapses all entries sharing a URL.
+	DedupGlobal DedupScope = iota
+	// DedupPerSource collapses entries sharing a URL only within one
+	// source feed. Entries for the same URL from different sources are
+	// kept and cross-linked through AlsoIn.
+	DedupPerSource
+)
+
+var dedupScopeNames = map[DedupScope]string{
+	DedupGlobal:    "global",
+	DedupPerSource: "per-source",
+}
+
+// String returns the CLI name of the scope.
+func (s DedupScope) String() string {
+	if name, ok := dedupScopeNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("DedupScope(%d)", int(s))
+}
+
+// ParseDedupScope parses a scope name: "global" or "per-source".
+func ParseDedupScope(name string) (DedupScope, error) {
+	for s, n := range dedupScopeNames {
+		if strings.EqualFold(name, n) {
+			return s, nil
+		}
+	}
+	return DedupGlobal, fmt.Errorf("unknown dedup scope %q (want global or per-source)", name)
+}
+
+// DedupKey returns the key under which scope considers entries duplicates:
+// the normalized URL, prefixed with the source feed for DedupPerSource.
+func DedupKey(e Entry, scope DedupScope) string {
+	key := strings.ToLower(strings.TrimRight(e.URL, "/"))
+	if scope == DedupPerSource {
+		key = sourceKey(e) + "\x00" + key
+	}
+	return key
+}
+
+// sourceKey identifies the source feed of an entry.
+func sourceKey(e Entry) string {
+	if e.Feed.URL != "" {
+		return strings.ToLower(strings.TrimRight(e.Feed.URL, "/"))
+	}
+	return e.Feed.Title
+}
+
 // Deduplicate removes duplicate entries based on URL.
 // When duplicates are found, it merges discussions and prefers priority entries.
 func (f *Feed) Deduplicate() {
-	seen := make(map[string]int) // URL -> index in unique slice
+	f.DeduplicateScoped(DedupGlobal)
+}
+
+// DuplicateGroup describes entries that Deduplicate collapsed into one
+// because they share a URL.
+type DuplicateGroup struct {
+	URL     string            `json:"url"` // URL of the kept entry
+	Title   string            `json:"title,omitempty"`
+	Members []DuplicateMember `json:"members"` // Every collapsed entry, kept entry first
+}
+
+// DuplicateMember identifies one entry of a DuplicateGroup and the feed it
+// came from.
+type DuplicateMember struct {
+	URL       string `json:"url"`
+	FeedTitle string `json:"feedTitle,omitempty"`
+	FeedURL   string `json:"feedUrl,omitempty"`
+}
+
+// DeduplicateWithReport is like Deduplicate but also returns a group for
+// each URL that appeared more than once, in order of first appearance.
+// The report shows which sources overlap.
+func (f *Feed) DeduplicateWithReport() []DuplicateGroup {
+	return f.DeduplicateScoped(DedupGlobal)
+}
+
+// DeduplicateScoped is like DeduplicateWithReport with control over the
+// scope. With DedupPerSource, entries sharing a URL across sources are all
+// kept, and each lists the others in AlsoIn.
+func (f *Feed) DeduplicateScoped(scope DedupScope) []DuplicateGroup {
+	seen := make(map[string]int) // dedup key -> index in unique slice
 	var unique []Entry
+	var members [][]DuplicateMember // parallel to unique
 	for _, e := range f.Entries {
-		normalizedURL := strings.ToLower(strings.TrimRight(e.URL, "/"))
-		if idx, exists := seen[normalizedURL]; exists {
-			// Merge discussions from duplicate into existing entry
-			if len(e.Discussions) > 0 {
-				unique[idx].Discussions = mergeDiscussions(unique[idx].Discussions, e.Discussions)
+		member := DuplicateMember{URL: e.URL, FeedTitle: e.Feed.Title, FeedURL: e.Feed.URL}
+		key := DedupKey(e, scope)
+		if idx, exists := seen[key]; exists {
+			absorbDuplicate(&unique[idx], e)
+			members[idx] = append(members[idx], member)
+		} else {
+			seen[key] = len(unique)
+			unique = append(unique, e)
+			members = append(members, []DuplicateMember{member})
+		}
+	}
+	f.Entries = unique
+	f.resolveIDCollisions(scope)
+	if scope == DedupPerSource {
+		f.linkAlsoIn()
+	}
+
+	var groups []DuplicateGroup
+	for i, m := range members {
+		if len(m) > 1 {
+			groups = append(groups, DuplicateGroup{URL: unique[i].URL, Title: unique[i].Title, Members: m})
+		}
+	}
+	return groups
+}
+
+// linkAlsoIn sets each entry's AlsoIn to the other entries with its URL,
+// replacing any earlier links.
+func (f *Feed) linkAlsoIn() {
+	byURL := make(map[string][]int)
+	for i := range f.Entries {
+		key := DedupKey(f.Entries[i], DedupGlobal)
+		byURL[key] = append(byURL[key], i)
+	}
+	for i := range f.Entries {
+		f.Entries[i].AlsoIn = nil
+		for _, j := range byURL[DedupKey(f.Entries[i], DedupGlobal)] {
+			if j == i {
+				continue
 			}
-			// If duplicate is a priority entry, upgrade the existing entry
-			if e.IsPriority && !unique[idx].IsPriority {
-				unique[idx].IsPriority = true
-				unique[idx].PriorityRank = e.PriorityRank
+			other := f.Entries[j]
+			f.Entries[i].AlsoIn = append(f.Entries[i].AlsoIn, AlsoIn{
+				ID:        other.ID,
+				FeedTitle: other.Feed.Title,
+				FeedURL:   other.Feed.URL,
+			})
+		}
+	}
+}
+
+// ResolveIDCollisions gives a new ID to any entry whose ID is also used by
+// an entry with a different URL, so by-* references stay unambiguous. The
+// entry with the lowest normalized URL keeps its ID, so the outcome does
+// not depend on entry order; the others get a longer hash salted with
+// their title, plus a numeric suffix if that is taken too. It returns the
+// number of IDs changed. Deduplicate calls it, so entries sharing a URL
+// have already been merged.
+func (f *Feed) ResolveIDCollisions() int {
+	return f.resolveIDCollisions(DedupGlobal)
+}
+
+// resolveIDCollisions is ResolveIDCollisions where entries are distinct
+// when their DedupKey for scope differs, so per-source duplicates of one
+// URL get distinct IDs.
+func (f *Feed) resolveIDCollisions(scope DedupScope) int {
+	owners := make(map[string]string, len(f.Entries)) // ID -> dedup key
+	for _, e := range f.Entries {
+		key := DedupKey(e, scope)
+		if owner, taken := owners[e.ID]; !taken || key < owner {
+			owners[e.ID] = key
+		}
+	}
+	changed := 0
+	for i := range f.Entries {
+		e := &f.Entries[i]
+		key := DedupKey(*e, scope)
+		if owners[e.ID] == key {
+			continue
+		}
+		id := generateLongID(e.URL, e.Date, e.Title)
+		for n := 2; ; n++ {
+			if _, taken := owners[id]; !taken {
+				break
+			}
+			id = fmt.Sprintf("%s-%d", generateLongID(e.URL, e.Date, e.Title), n)
+		}
+		e.ID = id
+		owners[id] = key
+		changed++
+	}
+	return changed
+}
+
+// DefaultTitleDedupWindow is the default window for DeduplicateByTitle.
+const DefaultTitleDedupWindow = 48 * time.Hour
+
+// DeduplicateByTitle removes entries sharing a normalized title and author
+// with another entry published within window of it. This catches articles
+// republished under a different URL. The entry with more content is kept,
+// and discussions and priority are merged as in Deduplicate.
+// Entries without a title are never collapsed.
+func (f *Feed) DeduplicateByTitle(window time.Duration) {
+	groups := make(map[string][]int) // title key -> indexes in unique slice
+	var unique []Entry
+	for _, e := range f.Entries {
+		key := titleKey(e)
+		if key == "" {
+			unique = append(unique, e)
+			continue
+		}
+		matched := -1
+		for _, idx := range groups[key] {
+			diff := unique[idx].Date.Sub(e.Date)
+			if diff < 0 {
+				diff = -diff
 			}
+			if diff <= window {
+				matched = idx
+				break
+			}
+		}
+		if matched < 0 {
+			groups[key] = append(groups[key], len(unique))
+			unique = append(unique, e)
+			continue
+		}
+		if len(e.Content) > len(unique[matched].Content) {
+			absorbDuplicate(&e, unique[matched])
+			unique[matched] = e
 		} else {
-			seen[normalizedURL] = len(unique)
+			absorbDuplicate(&unique[matched], e)
+		}
+	}
+	f.Entries = unique
+}
+
+// titleKey returns the normalized title+author key used by DeduplicateByTitle.
+func titleKey(e Entry) string {
+	title := normalizeText(e.Title)
+	if title == "" {
+		return ""
+	}
+	return title + "\x00" + normalizeText(e.Author)
+}
+
+// normalizeText lowercases s, drops punctuation, and collapses whitespace.
+func normalizeText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// minSummaryDedupLength is the shortest normalized summary DedupeBySummary
+// compares, so boilerplate like "Read more" doesn't collapse distinct posts.
+const minSummaryDedupLength = 40
+
+// DedupeBySummary removes entries from the same source whose summaries are
+// identical after stripping HTML, punctuation, case, and whitespace. This
+// catches feeds that publish both a teaser and a full item for one post
+// under different URLs. The entry with more content is kept, and
+// discussions and priority are merged as in Deduplicate.
+func (f *Feed) DedupeBySummary() {
+	seen := make(map[string]int) // source + summary key -> index in unique slice
+	var unique []Entry
+	for _, e := range f.Entries {
+		summary := normalizeText(HTMLToText(e.Summary))
+		if len(summary) < minSummaryDedupLength {
+			unique = append(unique, e)
+			continue
+		}
+		key := e.Feed.URL + "\x00" + e.Feed.Title + "\x00" + summary
+		idx, ok := seen[key]
+		if !ok {
+			seen[key] = len(unique)
 			unique = append(unique, e)
+			continue
+		}
+		if len(e.Content) > len(unique[idx].Content) {
+			absorbDuplicate(&e, unique[idx])
+			unique[idx] = e
+		} else {
+			absorbDuplicate(&unique[idx], e)
 		}
 	}
 	f.Entries = unique
 }
 
-// mergeDiscussions combines two discussion slices, avoiding duplicates by URL.
-func mergeDiscussions(existing, incoming []Discussion) []Discussion {
+// absorbDuplicate merges a duplicate entry's discussions and priority
+// status into the entry being kept.
+func absorbDuplicate(kept *Entry, dup Entry) {
+	// Merge discussions from duplicate into existing entry
+	if len(dup.Discussions) > 0 {
+		kept.Discussions = MergeDiscussions(kept.Discussions, dup.Discussions)
+	}
+	// If duplicate is a priority entry, upgrade the existing entry
+	if dup.IsPriority && !kept.IsPriority {
+		kept.IsPriority = true
+		kept.PriorityRank = dup.PriorityRank
+		kept.PriorityPeriod = dup.PriorityPeriod
+	}
+}
+
+// MergeDiscussions returns a new slice of the existing discussions followed
+// by the incoming ones whose URL is not already present. Its inputs are not
+// changed.
+func MergeDiscussions(existing, incoming []Discussion) []Discussion {
 	seen := make(map[string]bool)
 	for _, d := range existing {
 		seen[d.URL] = true
 	}
-	result := existing
+	result := append([]Discussion(nil), existing...)
 	for _, d := range incoming {
 		if !seen[d.URL] {
 			seen[d.URL] = true
@@ -159,13 +551,63 @@ func (f *Feed) FilterByTags(tags []string) []Entry {
 	return filtered
 }
 
+// FilterByDateRange returns entries dated within the half-open window
+// [start, end). A zero start or end leaves that side of the window open.
+func (f *Feed) FilterByDateRange(start, end time.Time) []Entry {
+	var filtered []Entry
+	for _, e := range f.Entries {
+		if !start.IsZero() && e.Date.Before(start) {
+			continue
+		}
+		if !end.IsZero() && !e.Date.Before(end) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
 // WriteJSON writes the feed to a JSON file.
 func (f *Feed) WriteJSON(filename string) error {
+	return f.WriteJSONMode(filename, 0644)
+}
+
+// WriteJSONMode is like WriteJSON, with the file's permissions set to perm.
+func (f *Feed) WriteJSONMode(filename string, perm os.FileMode) error {
 	data, err := json.MarshalIndent(f, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filename, data, 0644)
+	return fileutil.WriteFile(filename, data, perm)
+}
+
+// WriteNDJSON writes the feed's entries as newline-delimited JSON, one
+// Entry object per line, for streaming into tools like jq or ClickHouse.
+func (f *Feed) WriteNDJSON(filename string) error {
+	return f.WriteNDJSONMode(filename, 0644)
+}
+
+// WriteNDJSONMode is like WriteNDJSON, with the file's permissions set to
+// perm.
+func (f *Feed) WriteNDJSONMode(filename string, perm os.FileMode) (err error) {
+	file, err := fileutil.Create(filename, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w) // Encode terminates each value with a newline
+	for _, e := range f.Entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
 }
 
 // ReadJSON reads a feed from a JSON file.
@@ -184,23 +626,39 @@ func ReadJSON(filename string) (*Feed, error) {
 // ToJSONFeed converts the internal Feed to a JSON Feed 1.1 format.
 func (f *Feed) ToJSONFeed() *jsonfeed.Feed {
 	jf := jsonfeed.NewFeed(f.Title)
+	if !f.Generated.IsZero() {
+		jf.SetGenerated(f.Generated)
+	}
 	jf.HomePageURL = f.HomeURL
 	jf.Description = f.Description
 
+	titles := tagTitles(f.Entries)
 	for _, e := range f.Entries {
 		item := jsonfeed.Item{
-			ID:              e.ID,
-			URL:             e.URL,
-			Title:           e.Title,
-			Summary:         e.Summary,
-			ContentHTML:     e.Content,
-			Image:           e.Image,
-			DatePublished:   e.Date.Format(time.RFC3339),
-			Tags:            e.Tags,
-			SignalFeedTitle: e.Feed.Title,
-			SignalFeedURL:   e.Feed.URL,
-			SignalPriority:  e.IsPriority,
-			SignalRank:      e.PriorityRank,
+			ID:                     e.ID,
+			URL:                    e.URL,
+			ExternalURL:            e.ExternalURL,
+			Title:                  e.Title,
+			Language:               e.Language,
+			Summary:                e.Summary,
+			ContentHTML:            e.Content,
+			ContentText:            HTMLToText(e.Content),
+			Image:                  e.Image,
+			DatePublished:          e.Date.Format(time.RFC3339),
+			Tags:                   canonicalTags(e.Tags, titles),
+			SignalFeedTitle:        e.Feed.Title,
+			SignalFeedURL:          e.Feed.URL,
+			SignalPriority:         e.IsPriority,
+			SignalRank:             e.PriorityRank,
+			SignalPriorityPeriod:   e.PriorityPeriod,
+			SignalRaw:              e.Raw,
+			SignalOutlineTags:      e.OutlineTags,
+			SignalContentTruncated: e.ContentTruncated,
+			SignalWordCount:        e.WordCount,
+			SignalImageAlt:         e.ImageAlt,
+		}
+		if e.ImageMeta != nil {
+			item.SignalImageMeta = &jsonfeed.SignalImageMeta{Width: e.ImageMeta.Width, Height: e.ImageMeta.Height}
 		}
 
 		if e.Author != "" {
@@ -218,6 +676,14 @@ func (f *Feed) ToJSONFeed() *jsonfeed.Feed {
 			})
 		}
 
+		for _, a := range e.AlsoIn {
+			item.SignalAlsoIn = append(item.SignalAlsoIn, jsonfeed.SignalAlsoIn{
+				ID:        a.ID,
+				FeedTitle: a.FeedTitle,
+				FeedURL:   a.FeedURL,
+			})
+		}
+
 		// Copy source metadata
 		if e.Source != nil {
 			item.SignalSource = &jsonfeed.SignalSource{
@@ -0,0 +1,120 @@
+package entry
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+)
+
+// feedOf returns a feed with an entry per ID, in order.
+func feedOf(ids ...string) *Feed {
+	f := NewFeed("Test", "", "")
+	for _, id := range ids {
+		f.Entries = append(f.Entries, Entry{ID: id, URL: "https://example.com/" + id})
+	}
+	return f
+}
+
+// ids returns the IDs of f's entries, space-separated.
+func ids(f *Feed) string {
+	var s []string
+	for _, e := range f.Entries {
+		s = append(s, e.ID)
+	}
+	return strings.Join(s, " ")
+}
+
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want string
+	}{
+		{"fewer than entries", 2, "a b"},
+		{"as many as entries", 3, "a b c"},
+		{"more than entries", 10, "a b c"},
+		{"zero is a no-op", 0, "a b c"},
+		{"negative is a no-op", -1, "a b c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := feedOf("a", "b", "c")
+			f.Limit(tt.n)
+			if got := ids(f); got != tt.want {
+				t.Errorf("Limit(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByDateTies(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	orders := [][]string{
+		{"d", "b", "c", "a", "e"},
+		{"a", "b", "c", "d", "e"},
+		{"e", "c", "a", "b", "d"},
+	}
+	for _, order := range orders {
+		f := NewFeed("Test", "", "")
+		for _, id := range order {
+			date := newer
+			if id == "e" {
+				date = older
+			}
+			f.Entries = append(f.Entries, Entry{ID: id, Date: date})
+		}
+		f.SortByDate()
+		if got, want := ids(f), "a b c d e"; got != want {
+			t.Errorf("SortByDate of %q = %q, want %q", order, got, want)
+		}
+		f.SortByDateAsc()
+		if got, want := ids(f), "e a b c d"; got != want {
+			t.Errorf("SortByDateAsc of %q = %q, want %q", order, got, want)
+		}
+	}
+}
+
+func TestResolveIDCollisions(t *testing.T) {
+	date := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{ID: "same", URL: "https://example.com/b", Title: "B", Date: date},
+		{ID: "same", URL: "https://example.com/a", Title: "A", Date: date},
+		{ID: "other", URL: "https://example.com/c", Title: "C", Date: date},
+	}
+
+	resolve := func(entries []Entry) map[string]string {
+		f := NewFeed("Test", "", "")
+		f.Entries = append([]Entry(nil), entries...)
+		if n := f.ResolveIDCollisions(); n != 1 {
+			t.Errorf("ResolveIDCollisions() = %d, want 1", n)
+		}
+		byURL := make(map[string]string) // URL -> ID
+		seen := make(map[string]bool)
+		for _, e := range f.Entries {
+			if seen[e.ID] {
+				t.Errorf("ID %q used twice", e.ID)
+			}
+			seen[e.ID] = true
+			byURL[e.URL] = e.ID
+		}
+		return byURL
+	}
+
+	got := resolve(entries)
+	if id := got["https://example.com/a"]; id != "same" {
+		t.Errorf("lowest URL has ID %q, want it to keep %q", id, "same")
+	}
+	if id := got["https://example.com/b"]; id != generateLongID("https://example.com/b", date, "B") {
+		t.Errorf("colliding entry has ID %q, want the long title-salted ID", id)
+	}
+	if id := got["https://example.com/c"]; id != "other" {
+		t.Errorf("unrelated entry has ID %q, want %q", id, "other")
+	}
+
+	reversed := []Entry{entries[2], entries[1], entries[0]}
+	if again := resolve(reversed); !maps.Equal(got, again) {
+		t.Errorf("IDs depend on entry order: %v vs %v", got, again)
+	}
+}
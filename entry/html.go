@@ -0,0 +1,182 @@
+package entry
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// blockElements start a new line when converting HTML to text.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"br": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"figcaption": true, "figure": true, "footer": true, "h1": true,
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "ol": true, "p": true,
+	"pre": true, "section": true, "table": true, "td": true, "th": true,
+	"tr": true, "ul": true,
+}
+
+// HTMLToText converts HTML to plain text. Tags are removed, entities are
+// decoded, script and style contents are dropped, and block elements
+// become line breaks. Whitespace within lines is collapsed and blank
+// lines are removed.
+func HTMLToText(s string) string {
+	if s == "" {
+		return ""
+	}
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	skip := 0 // depth inside script/style
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return collapseLines(b.String())
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(z.Text())
+			}
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if tag == "script" || tag == "style" {
+				if tt == html.StartTagToken {
+					skip++
+				} else if tt == html.EndTagToken && skip > 0 {
+					skip--
+				}
+			}
+			if blockElements[tag] {
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// WordCount returns the number of whitespace-separated words in the
+// visible text of s, as rendered by HTMLToText.
+func WordCount(s string) int {
+	return len(strings.Fields(HTMLToText(s)))
+}
+
+// collapseLines collapses whitespace within each line and drops empty lines.
+func collapseLines(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// voidElements have no end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// TruncatedMarker is appended to content cut short by TruncateHTML.
+const TruncatedMarker = "… (truncated)"
+
+// TruncateHTML shortens HTML to about maxRunes characters, counting
+// markup, reporting whether it was cut. Whole tags are kept or dropped, so
+// a huge inline image is removed rather than split; text is cut at a word
+// boundary. TruncatedMarker is appended and elements left open are closed,
+// which may add a few characters beyond maxRunes. HTML within maxRunes is
+// returned unchanged.
+func TruncateHTML(s string, maxRunes int) (string, bool) {
+	return TruncateHTMLWithMarker(s, maxRunes, TruncatedMarker)
+}
+
+// TruncateHTMLWithMarker is like TruncateHTML but appends marker, such as
+// "...", in place of TruncatedMarker.
+func TruncateHTMLWithMarker(s string, maxRunes int, marker string) (string, bool) {
+	if maxRunes <= 0 || utf8.RuneCountInString(s) <= maxRunes {
+		return s, false
+	}
+	budget := maxRunes - utf8.RuneCountInString(marker)
+
+	var b strings.Builder
+	used := 0         // runes written to b
+	var open []string // names of unclosed elements
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		n := utf8.RuneCount(raw)
+		if used+n > budget {
+			if tt == html.TextToken {
+				b.WriteString(cutText(string(raw), budget-used))
+			}
+			break
+		}
+		b.Write(raw)
+		used += n
+		switch tt {
+		case html.StartTagToken:
+			if name, _ := z.TagName(); !voidElements[string(name)] {
+				open = append(open, string(name))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == string(name) {
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	result := strings.TrimRightFunc(b.String(), unicode.IsSpace) + marker
+	for i := len(open) - 1; i >= 0; i-- {
+		result += "</" + open[i] + ">"
+	}
+	return result, true
+}
+
+// cutText returns at most n runes of raw HTML text without splitting a
+// character reference. Unless the cut falls at the end of a word, it moves
+// back to the last Unicode space in the second half, keeping words whole;
+// text without spaces, such as Japanese, is cut at n. Trailing spaces are
+// dropped.
+func cutText(raw string, n int) string {
+	runes := []rune(raw)
+	if n <= 0 {
+		return ""
+	}
+	if n >= len(runes) {
+		return raw
+	}
+	cut := runes[:n]
+	if i := lastRune(cut, '&'); i >= 0 && lastRune(cut[i:], ';') < 0 {
+		cut = cut[:i]
+	}
+	if len(cut) == n && !unicode.IsSpace(runes[n]) {
+		for i := len(cut) - 1; i > len(cut)/2; i-- {
+			if unicode.IsSpace(cut[i]) {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(cut), unicode.IsSpace)
+}
+
+// lastRune returns the index of the last r in runes, or -1.
+func lastRune(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
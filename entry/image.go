@@ -0,0 +1,37 @@
+package entry
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// FindImage returns the src and alt text of the <img> in an HTML fragment
+// whose src is want, or of its first image when want is empty. Tracking
+// pixels are skipped. It returns empty strings if there is no such image.
+func FindImage(s, want string) (src, alt string) {
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return "", ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if tok.Data != "img" || isTrackingPixel(tok) {
+				continue
+			}
+			src, alt = "", ""
+			for _, a := range tok.Attr {
+				switch a.Key {
+				case "src":
+					src = strings.TrimSpace(a.Val)
+				case "alt":
+					alt = strings.TrimSpace(a.Val)
+				}
+			}
+			if src != "" && (want == "" || src == want) {
+				return src, alt
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package entry
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// linkAttrs are the attributes AbsolutizeLinks rewrites.
+var linkAttrs = map[string]bool{
+	"href":   true,
+	"src":    true,
+	"poster": true,
+}
+
+// AbsolutizeLinks rewrites relative href, src, and poster attributes in an
+// HTML fragment to absolute URLs resolved against baseURL, including
+// protocol-relative "//host/..." references, which take the base's scheme.
+// Absolute URLs and fragment-only links ("#section") are left alone, as
+// is s when baseURL isn't an absolute URL. Tags without relative links
+// are copied through unchanged.
+func AbsolutizeLinks(s, baseURL string) string {
+	base, err := url.Parse(baseURL)
+	if s == "" || err != nil || !base.IsAbs() {
+		return s
+	}
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return b.String()
+		}
+		raw := string(z.Raw())
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			b.WriteString(raw)
+			continue
+		}
+
+		tok := z.Token()
+		changed := false
+		for i, attr := range tok.Attr {
+			if !linkAttrs[attr.Key] {
+				continue
+			}
+			if abs, ok := absolutize(base, attr.Val); ok {
+				tok.Attr[i].Val = abs
+				changed = true
+			}
+		}
+		if changed {
+			b.WriteString(tok.String())
+		} else {
+			b.WriteString(raw)
+		}
+	}
+}
+
+// absolutize resolves a relative reference against base, reporting false
+// if ref is empty, absolute, a fragment, or unparseable.
+func absolutize(base *url.URL, ref string) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	u, err := url.Parse(ref)
+	if err != nil || u.IsAbs() {
+		return "", false
+	}
+	return base.ResolveReference(u).String(), true
+}
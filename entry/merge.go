@@ -0,0 +1,182 @@
+package entry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeStrategy controls how MergeEntries resolves entries present in both
+// existing and new sets.
+type MergeStrategy int
+
+const (
+	// NewestWins replaces existing entries with freshly fetched ones,
+	// keeping discussions from both.
+	NewestWins MergeStrategy = iota
+	// KeepExisting keeps existing entries and ignores fresh duplicates.
+	KeepExisting
+	// FieldMerge keeps existing non-empty fields (preserving manual
+	// enrichment such as images) and fills empty ones from the fresh
+	// entry. Discussions from both are kept.
+	FieldMerge
+)
+
+var mergeStrategyNames = map[MergeStrategy]string{
+	NewestWins:   "newest-wins",
+	KeepExisting: "keep-existing",
+	FieldMerge:   "field-merge",
+}
+
+// String returns the CLI name of the strategy.
+func (s MergeStrategy) String() string {
+	if name, ok := mergeStrategyNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("MergeStrategy(%d)", int(s))
+}
+
+// ParseMergeStrategy parses a strategy name: "newest-wins", "keep-existing",
+// or "field-merge".
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	for s, n := range mergeStrategyNames {
+		if strings.EqualFold(name, n) {
+			return s, nil
+		}
+	}
+	return NewestWins, fmt.Errorf("unknown merge strategy %q (want newest-wins, keep-existing, or field-merge)", name)
+}
+
+// MergeEntries merges new entries with existing entries, matching them by
+// DedupKey for scope, so per-source duplicates kept by
+// Feed.DeduplicateScoped survive the merge. The strategy decides which
+// entry wins when both sets contain the same key, except that a new entry
+// with DateClamped keeps the existing entry's date. A new entry whose URL
+// matches nothing but whose ID matches an existing entry from the same
+// source feed, as with GUID-based IDs after a feed rewrites a URL, is
+// treated as that entry.
+func MergeEntries(existing, new []Entry, strategy MergeStrategy, scope DedupScope) []Entry {
+	// Build map of existing entries by dedup key
+	byURL := make(map[string]Entry)
+	keyByID := make(map[string]string)
+	for _, e := range existing {
+		key := DedupKey(e, scope)
+		byURL[key] = e
+		keyByID[e.ID] = key
+	}
+
+	// Add/update with new entries
+	for _, e := range new {
+		key := DedupKey(e, scope)
+		old, exists := byURL[key]
+		if oldKey, ok := keyByID[e.ID]; !exists && ok && byURL[oldKey].Feed.URL == e.Feed.URL {
+			old, exists = byURL[oldKey], true
+			delete(byURL, oldKey)
+			if strategy != NewestWins {
+				key = oldKey
+			}
+		}
+		switch {
+		case !exists:
+			byURL[key] = e
+		case strategy == NewestWins:
+			e.Discussions = MergeDiscussions(e.Discussions, old.Discussions)
+			if e.DateClamped && !old.Date.IsZero() {
+				// Clamped to this fetch; the stored copy holds the first one
+				e.Date = old.Date
+			}
+			byURL[key] = e
+		case strategy == FieldMerge:
+			byURL[key] = mergeFields(old, e)
+		default: // KeepExisting
+			byURL[key] = old
+		}
+	}
+
+	// Convert back to slice
+	result := make([]Entry, 0, len(byURL))
+	for _, e := range byURL {
+		result = append(result, e)
+	}
+
+	return result
+}
+
+// mergeFields returns existing with its empty fields filled from fresh.
+func mergeFields(existing, fresh Entry) Entry {
+	merged := existing
+	if merged.Title == "" {
+		merged.Title = fresh.Title
+	}
+	if merged.ExternalURL == "" {
+		merged.ExternalURL = fresh.ExternalURL
+	}
+	if merged.Author == "" {
+		merged.Author = fresh.Author
+	}
+	if merged.Date.IsZero() {
+		merged.Date = fresh.Date
+	}
+	if merged.Feed.Title == "" {
+		merged.Feed = fresh.Feed
+	}
+	if len(merged.Tags) == 0 {
+		merged.Tags = fresh.Tags
+		merged.OutlineTags = fresh.OutlineTags
+	}
+	if merged.Summary == "" {
+		merged.Summary = fresh.Summary
+	}
+	if merged.Language == "" {
+		merged.Language = fresh.Language
+	}
+	if merged.Content == "" {
+		merged.Content = fresh.Content
+		merged.ContentTruncated = fresh.ContentTruncated
+	}
+	if merged.WordCount == 0 {
+		merged.WordCount = fresh.WordCount
+	}
+	if merged.Image == "" {
+		merged.Image = fresh.Image
+		merged.ImageAlt = fresh.ImageAlt
+		merged.ImageMeta = fresh.ImageMeta
+	}
+	if merged.ImageMeta == nil && merged.Image == fresh.Image {
+		merged.ImageMeta = fresh.ImageMeta
+	}
+	if merged.Source == nil {
+		merged.Source = fresh.Source
+	}
+	merged.Discussions = MergeDiscussions(merged.Discussions, fresh.Discussions)
+	if fresh.IsPriority && !merged.IsPriority {
+		merged.IsPriority = true
+		merged.PriorityRank = fresh.PriorityRank
+		merged.PriorityPeriod = fresh.PriorityPeriod
+	}
+	if merged.PriorityPeriod == "" {
+		merged.PriorityPeriod = fresh.PriorityPeriod
+	}
+	return merged
+}
+
+// Merge combines other's entries into f with the NewestWins strategy,
+// deduplicating by URL; see MergeWithStrategy.
+func (f *Feed) Merge(other *Feed) {
+	f.MergeWithStrategy(other, NewestWins, DedupGlobal)
+}
+
+// MergeWithStrategy combines other's entries into f, resolving entries
+// present in both by strategy (other's entries count as the new ones),
+// then deduplicates f by scope and sorts it newest first. f keeps its
+// title and other metadata, but takes the newer Generated time of the two.
+func (f *Feed) MergeWithStrategy(other *Feed, strategy MergeStrategy, scope DedupScope) {
+	if other == nil {
+		return
+	}
+	f.Entries = MergeEntries(f.Entries, other.Entries, strategy, scope)
+	f.DeduplicateScoped(scope)
+	f.SortByDate()
+	if other.Generated.After(f.Generated) {
+		f.Generated = other.Generated
+	}
+}
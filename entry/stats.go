@@ -0,0 +1,131 @@
+package entry
+
+import (
+	"strings"
+	"time"
+)
+
+// UnknownSource is the source title Stats uses for entries without one.
+const UnknownSource = "Unknown"
+
+// FeedStats contains aggregate counts over a feed's entries.
+type FeedStats struct {
+	TotalEntries    int                     `json:"totalEntries"`
+	TotalSources    int                     `json:"totalSources"`
+	TotalTags       int                     `json:"totalTags"`
+	OldestEntry     time.Time               `json:"oldestEntry"`
+	NewestEntry     time.Time               `json:"newestEntry"`
+	EntriesByMonth  map[string]int          `json:"entriesByMonth"`  // "2006-01" -> count
+	EntriesBySource map[string]*SourceStats `json:"entriesBySource"` // source title -> stats
+	EntriesByTag    map[string]int          `json:"entriesByTag"`    // lowercase tag -> count
+	TagTitles       map[string]string       `json:"tagTitles"`       // lowercase tag -> display casing
+}
+
+// SourceStats contains aggregate counts for a single source.
+type SourceStats struct {
+	Title       string    `json:"title"`
+	Count       int       `json:"count"`
+	OldestEntry time.Time `json:"oldestEntry"`
+	NewestEntry time.Time `json:"newestEntry"`
+}
+
+// Stats tallies the feed's entries by month, source, and tag. Entries
+// without a source title are counted under UnknownSource. Tags are counted
+// case-insensitively, and each tag's display casing is its most frequent
+// original casing, with ties broken lexically.
+func (f *Feed) Stats() FeedStats {
+	s := FeedStats{
+		EntriesByMonth:  make(map[string]int),
+		EntriesBySource: make(map[string]*SourceStats),
+		EntriesByTag:    make(map[string]int),
+	}
+	tagCasings := make(map[string]map[string]int) // lowercase -> casing -> count
+
+	for _, e := range f.Entries {
+		s.TotalEntries++
+
+		// Date range
+		if s.OldestEntry.IsZero() || e.Date.Before(s.OldestEntry) {
+			s.OldestEntry = e.Date
+		}
+		if s.NewestEntry.IsZero() || e.Date.After(s.NewestEntry) {
+			s.NewestEntry = e.Date
+		}
+
+		// By month
+		s.EntriesByMonth[e.Date.Format("2006-01")]++
+
+		// By source
+		sourceTitle := e.Feed.Title
+		if sourceTitle == "" {
+			sourceTitle = UnknownSource
+		}
+		ss := s.EntriesBySource[sourceTitle]
+		if ss == nil {
+			ss = &SourceStats{
+				Title:       sourceTitle,
+				OldestEntry: e.Date,
+				NewestEntry: e.Date,
+			}
+			s.EntriesBySource[sourceTitle] = ss
+		}
+		ss.Count++
+		if e.Date.Before(ss.OldestEntry) {
+			ss.OldestEntry = e.Date
+		}
+		if e.Date.After(ss.NewestEntry) {
+			ss.NewestEntry = e.Date
+		}
+
+		// By tag
+		for _, tag := range e.Tags {
+			lower := strings.ToLower(tag)
+			s.EntriesByTag[lower]++
+			if tagCasings[lower] == nil {
+				tagCasings[lower] = make(map[string]int)
+			}
+			tagCasings[lower][tag]++
+		}
+	}
+
+	s.TagTitles = canonicalTagTitles(tagCasings)
+	s.TotalSources = len(s.EntriesBySource)
+	s.TotalTags = len(s.EntriesByTag)
+	return s
+}
+
+// canonicalTagTitles picks the display casing for each lowercase tag: the
+// most frequent original casing, with ties broken lexically so the result
+// doesn't depend on entry order.
+func canonicalTagTitles(casings map[string]map[string]int) map[string]string {
+	titles := make(map[string]string, len(casings))
+	for lower, counts := range casings {
+		best, bestCount := "", 0
+		for casing, count := range counts {
+			if count > bestCount || (count == bestCount && casing < best) {
+				best, bestCount = casing, count
+			}
+		}
+		titles[lower] = best
+	}
+	return titles
+}
+
+// DominantLanguage returns the most common language of entries, with ties
+// broken lexically, and whether every entry has that language. Entries
+// without a language count against unanimity but not toward any language.
+func DominantLanguage(entries []Entry) (lang string, unanimous bool) {
+	counts := make(map[string]int)
+	for _, e := range entries {
+		if e.Language != "" {
+			counts[e.Language]++
+		}
+	}
+	best := 0
+	for l, count := range counts {
+		if count > best || (count == best && l < lang) {
+			lang, best = l, count
+		}
+	}
+	return lang, lang != "" && best == len(entries)
+}
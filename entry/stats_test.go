@@ -0,0 +1,33 @@
+package entry
+
+import "testing"
+
+func TestStatsTagTitlesMixedCase(t *testing.T) {
+	tagSets := [][]string{{"ai"}, {"AI"}, {"AI", "golang"}, {"Golang"}, {"Rust"}}
+	want := map[string]string{
+		"ai":     "AI",     // most frequent casing
+		"golang": "Golang", // tie, broken lexically
+		"rust":   "Rust",
+	}
+
+	// The same titles result whatever the entry order
+	for _, reversed := range []bool{false, true} {
+		f := NewFeed("Test", "", "")
+		for i := range tagSets {
+			tags := tagSets[i]
+			if reversed {
+				tags = tagSets[len(tagSets)-1-i]
+			}
+			f.Entries = append(f.Entries, Entry{Tags: tags})
+		}
+		got := f.Stats().TagTitles
+		for lower, title := range want {
+			if got[lower] != title {
+				t.Errorf("reversed=%v: TagTitles[%q] = %q, want %q", reversed, lower, got[lower], title)
+			}
+		}
+		if len(got) != len(want) {
+			t.Errorf("reversed=%v: got %d tags, want %d: %v", reversed, len(got), len(want), got)
+		}
+	}
+}
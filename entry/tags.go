@@ -0,0 +1,106 @@
+package entry
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// ExpandTagHierarchy returns tags followed by the ancestors of each tag,
+// where parents maps a child tag to its parent (e.g., "RAG" → "LLMs" →
+// "AI"). Lookups and duplicate removal are case-insensitive, and ancestors
+// take the casing used in parents. A cycle in parents stops the walk.
+func ExpandTagHierarchy(tags []string, parents map[string]string) []string {
+	if len(parents) == 0 {
+		return tags
+	}
+	lowerParents := make(map[string]string, len(parents))
+	for child, parent := range parents {
+		lowerParents[strings.ToLower(child)] = parent
+	}
+
+	seen := make(map[string]bool, len(tags))
+	expanded := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if lower := strings.ToLower(t); !seen[lower] {
+			seen[lower] = true
+			expanded = append(expanded, t)
+		}
+	}
+	for _, t := range tags {
+		for parent, ok := lowerParents[strings.ToLower(t)]; ok; parent, ok = lowerParents[strings.ToLower(parent)] {
+			lower := strings.ToLower(parent)
+			if seen[lower] {
+				break
+			}
+			seen[lower] = true
+			expanded = append(expanded, parent)
+		}
+	}
+	return expanded
+}
+
+// NormalizeTagCase rewrites each entry's tags in the casing most used for
+// them across the feed (see FeedStats.TagTitles), dropping duplicates that
+// differ only in case. ToJSONFeed does the same for its output; running it
+// on a whole planet first keeps casing consistent across files written
+// from parts of it, such as monthly archives.
+func (f *Feed) NormalizeTagCase() {
+	titles := tagTitles(f.Entries)
+	for i := range f.Entries {
+		f.Entries[i].Tags = canonicalTags(f.Entries[i].Tags, titles)
+	}
+}
+
+// tagTitles returns the canonical casing of the entries' tags, keyed by
+// lowercase tag.
+func tagTitles(entries []Entry) map[string]string {
+	casings := make(map[string]map[string]int)
+	for _, e := range entries {
+		for _, tag := range e.Tags {
+			lower := strings.ToLower(tag)
+			if casings[lower] == nil {
+				casings[lower] = make(map[string]int)
+			}
+			casings[lower][tag]++
+		}
+	}
+	return canonicalTagTitles(casings)
+}
+
+// canonicalTags returns tags in their casing from titles, in order, without
+// empty tags or case-insensitive duplicates.
+func canonicalTags(tags []string, titles map[string]string) []string {
+	if len(tags) == 0 {
+		return tags
+	}
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		lower := strings.ToLower(tag)
+		if tag == "" || seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		if title, ok := titles[lower]; ok {
+			tag = title
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// ReadTagHierarchy reads a tag hierarchy from a JSON file holding an
+// object that maps each child tag to its parent, e.g., {"RAG": "LLMs",
+// "LLMs": "AI"}.
+func ReadTagHierarchy(filename string) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var parents map[string]string
+	if err := json.Unmarshal(data, &parents); err != nil {
+		return nil, err
+	}
+	return parents, nil
+}
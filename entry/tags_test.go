@@ -0,0 +1,34 @@
+package entry
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCanonicalTagsCollapsesCase(t *testing.T) {
+	got := canonicalTags([]string{"Go", "go", "GO"}, map[string]string{"go": "Go"})
+	if want := []string{"Go"}; !slices.Equal(got, want) {
+		t.Errorf("canonicalTags = %q, want %q", got, want)
+	}
+}
+
+func TestToJSONFeedTagCase(t *testing.T) {
+	f := NewFeed("Test", "", "")
+	f.Entries = []Entry{
+		{ID: "a", URL: "https://example.com/a", Tags: []string{"Go", "go", "GO"}},
+		{ID: "b", URL: "https://example.com/b", Tags: []string{"go", "Rust"}},
+		{ID: "c", URL: "https://example.com/c", Tags: []string{"go"}},
+	}
+
+	jf := f.ToJSONFeed()
+	want := map[string][]string{
+		"a": {"go"},
+		"b": {"go", "Rust"},
+		"c": {"go"},
+	}
+	for _, item := range jf.Items {
+		if !slices.Equal(item.Tags, want[item.ID]) {
+			t.Errorf("item %s tags = %q, want %q", item.ID, item.Tags, want[item.ID])
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Package envvar expands environment variable references in feed lists
+// and priority files.
+package envvar
+
+import (
+	"os"
+	"regexp"
+)
+
+var ref = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Expand replaces ${VAR} references in s with environment variable values.
+// Only the braced form is expanded so a literal "$" in URLs is left alone.
+// Unset variables expand to "".
+func Expand(s string) string {
+	return ref.ReplaceAllStringFunc(s, func(r string) string {
+		return os.Getenv(r[2 : len(r)-1])
+	})
+}
@@ -0,0 +1,28 @@
+// Package fileutil writes output files with exact permissions.
+package fileutil
+
+import "os"
+
+// WriteFile writes data to filename and sets its permissions to perm.
+// Unlike os.WriteFile, the permissions apply even when the file already
+// exists, and are not reduced by the umask.
+func WriteFile(filename string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(filename, data, perm); err != nil {
+		return err
+	}
+	return os.Chmod(filename, perm)
+}
+
+// Create opens filename for writing, truncating it, with its permissions
+// set to perm as WriteFile sets them.
+func Create(filename string, perm os.FileMode) (*os.File, error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Chmod(perm); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,59 @@
+package jsonfeed
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ExtensionPrefix is the JSON key prefix of Signal extension fields.
+const ExtensionPrefix = "_signal_"
+
+// Extension describes a Signal extension field.
+type Extension struct {
+	Field       string // JSON key, e.g., "_signal_priority"
+	Level       string // "feed" or "item"
+	Description string
+}
+
+// extensionDescriptions documents each extension by JSON key.
+var extensionDescriptions = map[string]string{
+	"_signal_generated":         "When the feed was generated",
+	"_signal_period":            `Month period for monthly archives (e.g., "2026-02")`,
+	"_signal_source_feed_url":   "RSS/Atom URL of the source, on by-source feeds",
+	"_signal_feed_title":        "Title of the source feed",
+	"_signal_feed_url":          "URL of the source feed",
+	"_signal_priority":          "Whether this is a hand-curated priority entry",
+	"_signal_rank":              "Priority rank of a curated entry (lower is higher priority)",
+	"_signal_priority_period":   `Curation period of a priority entry, from its priority file (e.g., "2026-02")`,
+	"_signal_discussions":       "Discussion links (platform, url, id, score, comments), e.g., Hacker News or Reddit",
+	"_signal_source":            "Source platform metadata (platform, author, postId), e.g., LinkedIn",
+	"_signal_outline_tags":      "Subset of tags assigned by the OPML outline rather than the feed item",
+	"_signal_content_truncated": "Set when content_html was cut to the configured size cap",
+	"_signal_word_count":        "Number of words in the item's visible text, counted before any size cap",
+	"_signal_image_alt":         "Alt text of the item's image",
+	"_signal_image_meta":        "Pixel dimensions (width, height) of the item's image, when fetched",
+	"_signal_also_in":           "Items for the same URL from other sources (id, feed_title, feed_url), when deduplicating per source",
+	"_signal_raw":               "Original feed item fields (guid, published, updated, categories), only when generated with raw output for debugging",
+}
+
+// Extensions returns the Signal extension fields of Feed and Item, read
+// from their JSON struct tags so documentation can't drift from the output.
+func Extensions() []Extension {
+	exts := structExtensions(reflect.TypeOf(Feed{}), "feed")
+	return append(exts, structExtensions(reflect.TypeOf(Item{}), "item")...)
+}
+
+func structExtensions(t reflect.Type, level string) []Extension {
+	var exts []Extension
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if strings.HasPrefix(name, ExtensionPrefix) {
+			exts = append(exts, Extension{
+				Field:       name,
+				Level:       level,
+				Description: extensionDescriptions[name],
+			})
+		}
+	}
+	return exts
+}
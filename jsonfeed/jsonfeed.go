@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"os"
 	"time"
+
+	"github.com/grokify/signal/internal/fileutil"
 )
 
 const (
@@ -30,8 +32,9 @@ type Feed struct {
 	Items       []Item   `json:"items"`
 
 	// Signal extensions (prefixed with underscore per JSON Feed spec)
-	SignalGenerated string `json:"_signal_generated,omitempty"`
-	SignalPeriod    string `json:"_signal_period,omitempty"` // e.g., "2026-02" for monthly files
+	SignalGenerated     string `json:"_signal_generated,omitempty"`
+	SignalPeriod        string `json:"_signal_period,omitempty"`          // e.g., "2026-02" for monthly files
+	SignalSourceFeedURL string `json:"_signal_source_feed_url,omitempty"` // Original RSS/Atom URL on by-source feeds
 }
 
 // Author represents a JSON Feed author.
@@ -60,12 +63,20 @@ type Item struct {
 	Attachments   []Attachment `json:"attachments,omitempty"`
 
 	// Signal extensions
-	SignalFeedTitle   string              `json:"_signal_feed_title,omitempty"`
-	SignalFeedURL     string              `json:"_signal_feed_url,omitempty"`
-	SignalPriority    bool                `json:"_signal_priority,omitempty"`
-	SignalRank        int                 `json:"_signal_rank,omitempty"`
-	SignalDiscussions []SignalDiscussion  `json:"_signal_discussions,omitempty"`
-	SignalSource      *SignalSource       `json:"_signal_source,omitempty"`
+	SignalFeedTitle        string             `json:"_signal_feed_title,omitempty"`
+	SignalFeedURL          string             `json:"_signal_feed_url,omitempty"`
+	SignalPriority         bool               `json:"_signal_priority,omitempty"`
+	SignalRank             int                `json:"_signal_rank,omitempty"`
+	SignalPriorityPeriod   string             `json:"_signal_priority_period,omitempty"`
+	SignalDiscussions      []SignalDiscussion `json:"_signal_discussions,omitempty"`
+	SignalSource           *SignalSource      `json:"_signal_source,omitempty"`
+	SignalRaw              map[string]any     `json:"_signal_raw,omitempty"`
+	SignalOutlineTags      []string           `json:"_signal_outline_tags,omitempty"`
+	SignalAlsoIn           []SignalAlsoIn     `json:"_signal_also_in,omitempty"`
+	SignalContentTruncated bool               `json:"_signal_content_truncated,omitempty"`
+	SignalWordCount        int                `json:"_signal_word_count,omitempty"`
+	SignalImageAlt         string             `json:"_signal_image_alt,omitempty"`
+	SignalImageMeta        *SignalImageMeta   `json:"_signal_image_meta,omitempty"`
 }
 
 // SignalSource represents metadata about the content source platform.
@@ -75,6 +86,19 @@ type SignalSource struct {
 	PostID   string `json:"postId,omitempty"` // Platform-specific post ID
 }
 
+// SignalAlsoIn links an item to another source's item for the same URL.
+type SignalAlsoIn struct {
+	ID        string `json:"id"`
+	FeedTitle string `json:"feed_title,omitempty"`
+	FeedURL   string `json:"feed_url,omitempty"`
+}
+
+// SignalImageMeta records the pixel dimensions of an item's image.
+type SignalImageMeta struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
 // SignalDiscussion represents a link to a discussion forum.
 type SignalDiscussion struct {
 	Platform string `json:"platform"`           // "hackernews", "reddit", "lobsters", etc.
@@ -96,13 +120,18 @@ type Attachment struct {
 // NewFeed creates a new JSON Feed with the required fields.
 func NewFeed(title string) *Feed {
 	return &Feed{
-		Version:        Version,
-		Title:          title,
-		Items:          []Item{},
+		Version:         Version,
+		Title:           title,
+		Items:           []Item{},
 		SignalGenerated: time.Now().UTC().Format(time.RFC3339),
 	}
 }
 
+// SetGenerated sets the generation timestamp, overriding the time set by NewFeed.
+func (f *Feed) SetGenerated(t time.Time) {
+	f.SignalGenerated = t.UTC().Format(time.RFC3339)
+}
+
 // AddItem adds an item to the feed.
 func (f *Feed) AddItem(item Item) {
 	f.Items = append(f.Items, item)
@@ -110,24 +139,16 @@ func (f *Feed) AddItem(item Item) {
 
 // WriteFile writes the feed to a JSON file.
 func (f *Feed) WriteFile(filename string) error {
-	data, err := json.MarshalIndent(f, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(filename, data, 0644)
+	return f.WriteFileMode(filename, 0644)
 }
 
-// ReadFile reads a feed from a JSON file.
-func ReadFile(filename string) (*Feed, error) {
-	data, err := os.ReadFile(filename)
+// WriteFileMode writes the feed to a JSON file with permissions perm.
+func (f *Feed) WriteFileMode(filename string, perm os.FileMode) error {
+	data, err := json.MarshalIndent(f, "", "  ")
 	if err != nil {
-		return nil, err
-	}
-	var feed Feed
-	if err := json.Unmarshal(data, &feed); err != nil {
-		return nil, err
+		return err
 	}
-	return &feed, nil
+	return fileutil.WriteFile(filename, data, perm)
 }
 
 // ToJSON returns the feed as indented JSON bytes.
@@ -0,0 +1,153 @@
+package jsonfeed
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version10 is the JSON Feed 1.0 version URL, accepted when reading.
+const Version10 = "https://jsonfeed.org/version/1"
+
+// dateLayouts are the date formats accepted when reading feeds from other
+// generators. JSON Feed requires RFC 3339, but RFC 1123 and bare dates
+// show up in the wild.
+var dateLayouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ReadFile reads a feed from a JSON file. See Parse.
+func ReadFile(filename string) (*Feed, []string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses a JSON Feed 1.0 or 1.1 document, normalizing it to 1.1:
+// the version is set to Version, and 1.0 "author" objects become
+// "authors". Item dates are accepted in RFC 3339, RFC 1123, and other
+// common layouts, or as Unix timestamps, and rewritten as RFC 3339.
+// Problems that don't prevent reading, such as an unknown version or an
+// unparseable date, are returned as warnings and the original value is kept.
+func Parse(data []byte) (*Feed, []string, error) {
+	var feed Feed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, nil, err
+	}
+
+	// JSON Feed 1.0 used a single author object instead of authors
+	var legacy struct {
+		Author *Author `json:"author"`
+		Items  []struct {
+			Author *Author `json:"author"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	switch feed.Version {
+	case Version, Version10:
+	default:
+		warnings = append(warnings, fmt.Sprintf("unknown version %q, reading as %s", feed.Version, Version))
+	}
+	feed.Version = Version
+
+	if len(feed.Authors) == 0 && legacy.Author != nil {
+		feed.Authors = []Author{*legacy.Author}
+	}
+	for i := range feed.Items {
+		item := &feed.Items[i]
+		if len(item.Authors) == 0 && i < len(legacy.Items) && legacy.Items[i].Author != nil {
+			item.Authors = []Author{*legacy.Items[i].Author}
+		}
+		for _, field := range []struct {
+			name  string
+			value *string
+		}{
+			{"date_published", &item.DatePublished},
+			{"date_modified", &item.DateModified},
+		} {
+			if *field.value == "" {
+				continue
+			}
+			t, err := ParseDate(*field.value)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("item %q: %s: %v", item.ID, field.name, err))
+				continue
+			}
+			*field.value = t.Format(time.RFC3339)
+		}
+	}
+	return &feed, warnings, nil
+}
+
+// ParseDate parses a feed date in any of the accepted layouts, or as a
+// Unix timestamp in seconds or milliseconds.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if len(s) >= 13 {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// UnmarshalJSON decodes an item, accepting numeric date_published and
+// date_modified values (Unix timestamps) by keeping their digits as strings.
+func (it *Item) UnmarshalJSON(data []byte) error {
+	type plain Item
+	aux := struct {
+		*plain
+		DatePublished json.RawMessage `json:"date_published,omitempty"`
+		DateModified  json.RawMessage `json:"date_modified,omitempty"`
+	}{plain: (*plain)(it)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if it.DatePublished, err = rawDate(aux.DatePublished); err != nil {
+		return fmt.Errorf("date_published: %w", err)
+	}
+	if it.DateModified, err = rawDate(aux.DateModified); err != nil {
+		return fmt.Errorf("date_modified: %w", err)
+	}
+	return nil
+}
+
+// rawDate returns a JSON date value as a string, keeping numbers as digits.
+func rawDate(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
@@ -2,32 +2,41 @@ package monthly
 
 import (
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/grokify/signal/entry"
 	"github.com/grokify/signal/jsonfeed"
 )
 
-// LoadExistingEntries loads all entries from existing monthly files in a directory.
-// This allows merging new entries with historical data.
-func LoadExistingEntries(dir, prefix string) ([]entry.Entry, error) {
-	var entries []entry.Entry
+// LoadExistingEntries loads entries from existing monthly files in a directory.
+// This allows merging new entries with historical data. A windowMonths
+// above 0 loads only that many of the most recent files, since older
+// months rarely change; 0 loads them all.
+func LoadExistingEntries(dir, prefix string, windowMonths int) ([]entry.Entry, error) {
+	return LoadExistingEntriesWithTemplate(dir, prefix, DefaultTemplate, windowMonths)
+}
 
-	pattern := filepath.Join(dir, prefix+"-*.json")
-	files, err := filepath.Glob(pattern)
+// LoadExistingEntriesWithTemplate is like LoadExistingEntries for files
+// named by tmpl, including files nested in directories.
+func LoadExistingEntriesWithTemplate(dir, prefix, tmpl string, windowMonths int) ([]entry.Entry, error) {
+	months, err := ExistingMonths(dir, prefix, tmpl)
 	if err != nil {
 		return nil, err
 	}
+	if windowMonths > 0 && len(months) > windowMonths {
+		months = months[len(months)-windowMonths:]
+	}
+	return LoadMonths(dir, prefix, tmpl, months), nil
+}
 
-	for _, file := range files {
-		// Skip if not a monthly file (e.g., skip index.json)
-		base := filepath.Base(file)
-		if !strings.HasPrefix(base, prefix+"-") {
-			continue
-		}
-
-		jf, err := jsonfeed.ReadFile(file)
+// LoadMonths loads the entries of the monthly files for months ("YYYY-MM")
+// named by tmpl, such as those ExistingMonths lists. Files that can't be
+// read are skipped.
+func LoadMonths(dir, prefix, tmpl string, months []string) []entry.Entry {
+	var entries []entry.Entry
+	for _, month := range months {
+		file := filepath.Join(dir, filepath.FromSlash(TemplateFilename(tmpl, prefix, month)))
+		jf, _, err := jsonfeed.ReadFile(file)
 		if err != nil {
 			// Skip files that can't be read
 			continue
@@ -39,30 +48,65 @@ func LoadExistingEntries(dir, prefix string) ([]entry.Entry, error) {
 		}
 	}
 
-	return entries, nil
+	return entries
 }
 
 // itemToEntry converts a JSON Feed item back to an internal Entry.
 func itemToEntry(item jsonfeed.Item) entry.Entry {
 	e := entry.Entry{
-		ID:      item.ID,
-		URL:     item.URL,
-		Title:   item.Title,
-		Summary: item.Summary,
-		Content: item.ContentHTML,
-		Tags:    item.Tags,
+		ID:               item.ID,
+		URL:              item.URL,
+		ExternalURL:      item.ExternalURL,
+		Title:            item.Title,
+		Language:         item.Language,
+		Summary:          item.Summary,
+		Content:          item.ContentHTML,
+		Tags:             item.Tags,
+		OutlineTags:      item.SignalOutlineTags,
+		ContentTruncated: item.SignalContentTruncated,
+		WordCount:        item.SignalWordCount,
+		Image:            item.Image,
+		ImageAlt:         item.SignalImageAlt,
 		Feed: entry.FeedMeta{
 			Title: item.SignalFeedTitle,
 			URL:   item.SignalFeedURL,
 		},
-		IsPriority:   item.SignalPriority,
-		PriorityRank: item.SignalRank,
+		IsPriority:     item.SignalPriority,
+		PriorityRank:   item.SignalRank,
+		PriorityPeriod: item.SignalPriorityPeriod,
 	}
 
 	if len(item.Authors) > 0 {
 		e.Author = item.Authors[0].Name
 	}
 
+	for _, d := range item.SignalDiscussions {
+		e.Discussions = append(e.Discussions, entry.Discussion{
+			Platform: d.Platform,
+			URL:      d.URL,
+			ID:       d.ID,
+			Score:    d.Score,
+			Comments: d.Comments,
+		})
+	}
+	for _, a := range item.SignalAlsoIn {
+		e.AlsoIn = append(e.AlsoIn, entry.AlsoIn{
+			ID:        a.ID,
+			FeedTitle: a.FeedTitle,
+			FeedURL:   a.FeedURL,
+		})
+	}
+	if item.SignalSource != nil {
+		e.Source = &entry.Source{
+			Platform: item.SignalSource.Platform,
+			Author:   item.SignalSource.Author,
+			PostID:   item.SignalSource.PostID,
+		}
+	}
+	if item.SignalImageMeta != nil {
+		e.ImageMeta = &entry.ImageMeta{Width: item.SignalImageMeta.Width, Height: item.SignalImageMeta.Height}
+	}
+
 	// Parse date
 	if item.DatePublished != "" {
 		if t, err := time.Parse(time.RFC3339, item.DatePublished); err == nil {
@@ -73,31 +117,33 @@ func itemToEntry(item jsonfeed.Item) entry.Entry {
 	return e
 }
 
-// MergeEntries merges new entries with existing entries, deduplicating by URL.
-// New entries take precedence over existing entries with the same URL.
-func MergeEntries(existing, new []entry.Entry) []entry.Entry {
-	// Build map of existing entries by normalized URL
-	byURL := make(map[string]entry.Entry)
-	for _, e := range existing {
-		key := normalizeURL(e.URL)
-		byURL[key] = e
-	}
-
-	// Add/update with new entries
-	for _, e := range new {
-		key := normalizeURL(e.URL)
-		byURL[key] = e
-	}
+// MergeStrategy controls how MergeEntries resolves entries present in both
+// existing and new sets; see entry.MergeStrategy.
+type MergeStrategy = entry.MergeStrategy
+
+const (
+	// NewestWins replaces existing entries with freshly fetched ones.
+	NewestWins = entry.NewestWins
+	// KeepExisting keeps existing entries and ignores fresh duplicates.
+	KeepExisting = entry.KeepExisting
+	// FieldMerge fills empty fields of existing entries from fresh ones.
+	FieldMerge = entry.FieldMerge
+)
 
-	// Convert back to slice
-	result := make([]entry.Entry, 0, len(byURL))
-	for _, e := range byURL {
-		result = append(result, e)
-	}
+// ParseMergeStrategy parses a strategy name: "newest-wins", "keep-existing",
+// or "field-merge".
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	return entry.ParseMergeStrategy(name)
+}
 
-	return result
+// MergeEntries merges new entries with existing entries, deduplicating by URL.
+// The strategy decides which entry wins when both sets contain the same URL.
+func MergeEntries(existing, new []entry.Entry, strategy MergeStrategy) []entry.Entry {
+	return entry.MergeEntries(existing, new, strategy, entry.DedupGlobal)
 }
 
-func normalizeURL(u string) string {
-	return strings.ToLower(strings.TrimRight(u, "/"))
+// MergeEntriesScoped is like MergeEntries but matches entries by
+// entry.DedupKey for scope; see entry.MergeEntries.
+func MergeEntriesScoped(existing, new []entry.Entry, strategy MergeStrategy, scope entry.DedupScope) []entry.Entry {
+	return entry.MergeEntries(existing, new, strategy, scope)
 }
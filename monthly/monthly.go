@@ -2,13 +2,20 @@
 package monthly
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/jsonfeed"
 )
 
 // MonthKey returns the month key for a given time (e.g., "2026-02").
@@ -16,6 +23,115 @@ func MonthKey(t time.Time) string {
 	return t.Format("2006-01")
 }
 
+// DefaultTemplate names monthly files like prefix-2026-02.json.
+const DefaultTemplate = "{prefix}-{year}-{month}.json"
+
+var templatePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateTemplate checks a monthly file naming template. Templates use
+// the placeholders {prefix}, {year}, and {month} (two digits), must
+// include {year} and {month}, and may nest files in directories with "/",
+// e.g., "{year}/{month}.json" or "{prefix}_{year}{month}.json". They must
+// stay within the output directory.
+func ValidateTemplate(tmpl string) error {
+	for _, p := range templatePlaceholder.FindAllString(tmpl, -1) {
+		if p != "{prefix}" && p != "{year}" && p != "{month}" {
+			return fmt.Errorf("monthly template %q: unknown placeholder %s (want {prefix}, {year}, or {month})", tmpl, p)
+		}
+	}
+	if !strings.Contains(tmpl, "{year}") || !strings.Contains(tmpl, "{month}") {
+		return fmt.Errorf("monthly template %q must include {year} and {month}", tmpl)
+	}
+	if path.IsAbs(tmpl) || filepath.IsAbs(tmpl) {
+		return fmt.Errorf("monthly template %q must be relative to the output directory", tmpl)
+	}
+	for _, elem := range strings.Split(tmpl, "/") {
+		if elem == ".." {
+			return fmt.Errorf("monthly template %q must not contain \"..\"", tmpl)
+		}
+	}
+	return nil
+}
+
+// TemplateFilename returns the slash-separated file name, relative to the
+// output directory, of a month (e.g., "2026-02") under tmpl. An empty
+// tmpl means DefaultTemplate.
+func TemplateFilename(tmpl, prefix, month string) string {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	year, mm, _ := strings.Cut(month, "-")
+	return strings.NewReplacer("{prefix}", prefix, "{year}", year, "{month}", mm).Replace(tmpl)
+}
+
+// templateGlob returns a filepath.Glob pattern under dir matching the
+// files tmpl names.
+func templateGlob(dir, tmpl, prefix string) string {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	pattern := strings.NewReplacer(
+		"{prefix}", prefix,
+		"{year}", "[0-9][0-9][0-9][0-9]",
+		"{month}", "[0-9][0-9]",
+	).Replace(tmpl)
+	return filepath.Join(dir, filepath.FromSlash(pattern))
+}
+
+// templateRegexp returns a regexp matching the slash-separated file names,
+// relative to the output directory, that tmpl gives prefix, capturing the
+// year and month.
+func templateRegexp(tmpl, prefix string) (*regexp.Regexp, error) {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, loc := range templatePlaceholder.FindAllStringIndex(tmpl, -1) {
+		b.WriteString(regexp.QuoteMeta(tmpl[last:loc[0]]))
+		switch tmpl[loc[0]:loc[1]] {
+		case "{prefix}":
+			b.WriteString(regexp.QuoteMeta(prefix))
+		case "{year}":
+			b.WriteString(`(?P<year>[0-9]{4})`)
+		case "{month}":
+			b.WriteString(`(?P<month>[0-9]{2})`)
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(tmpl[last:]))
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// ExistingMonths returns the months (e.g., "2026-02") that have a monthly
+// file under dir named by tmpl, oldest first.
+func ExistingMonths(dir, prefix, tmpl string) ([]string, error) {
+	files, err := filepath.Glob(templateGlob(dir, tmpl, prefix))
+	if err != nil {
+		return nil, err
+	}
+	re, err := templateRegexp(tmpl, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var months []string
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			continue
+		}
+		m := re.FindStringSubmatch(filepath.ToSlash(rel))
+		if m == nil {
+			continue
+		}
+		months = append(months, m[re.SubexpIndex("year")]+"-"+m[re.SubexpIndex("month")])
+	}
+	sort.Strings(months)
+	return months, nil
+}
+
 // SplitByMonth splits a feed's entries into monthly buckets.
 func SplitByMonth(f *entry.Feed) map[string]*entry.Feed {
 	buckets := make(map[string]*entry.Feed)
@@ -38,22 +154,74 @@ func SplitByMonth(f *entry.Feed) map[string]*entry.Feed {
 }
 
 // WriteMonthlyFiles writes entries to monthly JSON Feed files.
-// Files are named like: prefix-2026-02.json
+// Files are named like: prefix-2026-02.json (see WriteOptions.Template)
 // Output uses JSON Feed 1.1 format (https://jsonfeed.org/version/1.1)
 func WriteMonthlyFiles(f *entry.Feed, outputDir, prefix string) ([]string, error) {
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	return WriteMonthlyFilesWithOptions(f, outputDir, prefix, WriteOptions{})
+}
+
+// WriteOptions controls how WriteMonthlyFilesWithOptions writes files.
+type WriteOptions struct {
+	FileMode os.FileMode // Permissions for written files (0 = 0644)
+	DirMode  os.FileMode // Permissions for a created output directory (0 = 0755, before umask)
+
+	// OmitGenerated leaves _signal_generated out of the files
+	OmitGenerated bool
+
+	// Template names the files; see ValidateTemplate ("" = DefaultTemplate)
+	Template string
+
+	// Language is the feed-level language of the files, e.g., "en"
+	Language string
+
+	// ExpireAfterMonths marks months more than this many months before the
+	// feed's generation month as expired, telling JSON Feed clients to stop
+	// polling them (0 = never expire)
+	ExpireAfterMonths int
+}
+
+// WriteMonthlyFilesWithOptions is like WriteMonthlyFiles with control over
+// permissions, file naming, and expiry of old months.
+func WriteMonthlyFilesWithOptions(f *entry.Feed, outputDir, prefix string, opts WriteOptions) ([]string, error) {
+	if opts.FileMode == 0 {
+		opts.FileMode = 0644
+	}
+	if opts.DirMode == 0 {
+		opts.DirMode = 0755
+	}
+	if err := os.MkdirAll(outputDir, opts.DirMode); err != nil {
 		return nil, err
 	}
 
+	// Months before expireBefore are expired
+	expireBefore := ""
+	if opts.ExpireAfterMonths > 0 {
+		ref := f.Generated
+		if ref.IsZero() {
+			ref = time.Now()
+		}
+		// Count back from the 1st, as AddDate would roll March 31 minus
+		// one month over into March
+		expireBefore = MonthKey(time.Date(ref.Year(), ref.Month()-time.Month(opts.ExpireAfterMonths), 1, 0, 0, 0, 0, time.UTC))
+	}
+
 	buckets := SplitByMonth(f)
 	var files []string
 
 	for month, monthFeed := range buckets {
-		filename := filepath.Join(outputDir, fmt.Sprintf("%s-%s.json", prefix, month))
+		filename := filepath.Join(outputDir, filepath.FromSlash(TemplateFilename(opts.Template, prefix, month)))
+		if err := os.MkdirAll(filepath.Dir(filename), opts.DirMode); err != nil {
+			return files, err
+		}
 		// Convert to JSON Feed format and set the period
 		jf := monthFeed.ToJSONFeed()
 		jf.SignalPeriod = month
-		if err := jf.WriteFile(filename); err != nil {
+		jf.Expired = month < expireBefore
+		jf.Language = opts.Language
+		if opts.OmitGenerated {
+			jf.SignalGenerated = ""
+		}
+		if err := jf.WriteFileMode(filename, opts.FileMode); err != nil {
 			return files, fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 		files = append(files, filename)
@@ -67,6 +235,7 @@ func WriteMonthlyFiles(f *entry.Feed, outputDir, prefix string) ([]string, error
 type Index struct {
 	Generated time.Time `json:"generated"`
 	Title     string    `json:"title,omitempty"`
+	Hash      string    `json:"hash"` // Changes when any file's name, count, or entries change
 	Files     []FileRef `json:"files"`
 }
 
@@ -79,13 +248,19 @@ type FileRef struct {
 
 // GenerateIndex creates an index of monthly files.
 func GenerateIndex(f *entry.Feed, prefix string) *Index {
+	return GenerateIndexWithTemplate(f, prefix, DefaultTemplate)
+}
+
+// GenerateIndexWithTemplate is like GenerateIndex for files named by tmpl.
+// Filenames are slash-separated paths relative to the output directory.
+func GenerateIndexWithTemplate(f *entry.Feed, prefix, tmpl string) *Index {
 	buckets := SplitByMonth(f)
 
 	var files []FileRef
 	for month, monthFeed := range buckets {
 		files = append(files, FileRef{
 			Month:    month,
-			Filename: fmt.Sprintf("%s-%s.json", prefix, month),
+			Filename: TemplateFilename(tmpl, prefix, month),
 			Count:    len(monthFeed.Entries),
 		})
 	}
@@ -96,12 +271,94 @@ func GenerateIndex(f *entry.Feed, prefix string) *Index {
 	})
 
 	return &Index{
-		Generated: time.Now().UTC(),
+		Generated: f.Generated,
 		Title:     f.Title,
+		Hash:      indexHash(files, buckets),
 		Files:     files,
 	}
 }
 
+// ReadIndex reads an index file written from GenerateIndex.
+func ReadIndex(filename string) (*Index, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
+}
+
+// FileRefs returns index references to the existing files of months under
+// dir, e.g., those a windowed merge left untouched. Counts come from prev,
+// which may be nil, and only files it doesn't list are read.
+func FileRefs(dir, prefix, tmpl string, months []string, prev *Index) []FileRef {
+	counts := make(map[string]int)
+	if prev != nil {
+		for _, ref := range prev.Files {
+			counts[ref.Month] = ref.Count
+		}
+	}
+	var refs []FileRef
+	for _, month := range months {
+		filename := TemplateFilename(tmpl, prefix, month)
+		count, ok := counts[month]
+		if !ok {
+			jf, _, err := jsonfeed.ReadFile(filepath.Join(dir, filepath.FromSlash(filename)))
+			if err != nil {
+				continue
+			}
+			count = len(jf.Items)
+		}
+		refs = append(refs, FileRef{Month: month, Filename: filename, Count: count})
+	}
+	return refs
+}
+
+// AddFiles adds refs for months the index doesn't list, keeping files
+// sorted newest first. The hash then also covers their names and counts;
+// their entries aren't loaded, so changes to them alone aren't detected.
+func (idx *Index) AddFiles(refs []FileRef) {
+	listed := make(map[string]bool, len(idx.Files))
+	for _, ref := range idx.Files {
+		listed[ref.Month] = true
+	}
+	h := sha256.New()
+	h.Write([]byte(idx.Hash))
+	added := 0
+	for _, ref := range refs {
+		if listed[ref.Month] {
+			continue
+		}
+		listed[ref.Month] = true
+		idx.Files = append(idx.Files, ref)
+		fmt.Fprintf(h, "%s %d\n", ref.Filename, ref.Count)
+		added++
+	}
+	if added == 0 {
+		return
+	}
+	sort.Slice(idx.Files, func(i, j int) bool {
+		return idx.Files[i].Month > idx.Files[j].Month
+	})
+	idx.Hash = hex.EncodeToString(h.Sum(nil))
+}
+
+// indexHash digests each file's name and count and its month's entries,
+// in file order, so clients can detect any change to the monthly files by
+// comparing one value.
+func indexHash(files []FileRef, buckets map[string]*entry.Feed) string {
+	h := sha256.New()
+	for _, ref := range files {
+		data, _ := json.Marshal(buckets[ref.Month].Entries)
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(h, "%s %d %x\n", ref.Filename, ref.Count, sum)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // LatestMonths returns the most recent N months of entries as a single feed.
 func LatestMonths(f *entry.Feed, n int) *entry.Feed {
 	buckets := SplitByMonth(f)
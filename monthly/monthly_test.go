@@ -0,0 +1,97 @@
+package monthly
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/jsonfeed"
+)
+
+func TestWriteMonthlyFilesExpiresByCalendarMonth(t *testing.T) {
+	feed := entry.NewFeed("Test", "", "")
+	feed.Generated = time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	for _, d := range []time.Time{
+		time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	} {
+		feed.Entries = append(feed.Entries, entry.Entry{ID: d.Format("0102"), URL: "https://example.com/" + d.Format("01"), Date: d})
+	}
+
+	dir := t.TempDir()
+	if _, err := WriteMonthlyFilesWithOptions(feed, dir, "feeds", WriteOptions{ExpireAfterMonths: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{"2026-01": true, "2026-02": false, "2026-03": false}
+	for month, expired := range want {
+		jf, _, err := jsonfeed.ReadFile(filepath.Join(dir, "feeds-"+month+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if jf.Expired != expired {
+			t.Errorf("%s: expired = %v, want %v", month, jf.Expired, expired)
+		}
+	}
+}
+
+func TestMergeKeepsDiscussionsAcrossRuns(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	hn := entry.Discussion{Platform: "hackernews", URL: "https://news.ycombinator.com/item?id=1", ID: "1", Score: 42}
+	reddit := entry.Discussion{Platform: "reddit", URL: "https://www.reddit.com/r/golang/comments/abc/"}
+	source := &entry.Source{Platform: "mastodon", Author: "@ada", PostID: "99"}
+
+	for _, strategy := range []MergeStrategy{NewestWins, FieldMerge} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			dir := t.TempDir()
+
+			// First run: an entry enriched with a Hacker News discussion
+			first := entry.NewFeed("Test", "", "")
+			first.Entries = []entry.Entry{{
+				ID: "a", URL: "https://example.com/a", Title: "A", Date: date,
+				Discussions: []entry.Discussion{hn}, Source: source,
+			}}
+			if _, err := WriteMonthlyFiles(first, dir, "feeds"); err != nil {
+				t.Fatal(err)
+			}
+
+			// Second run: the same entry fetched again, now also on Reddit
+			existing, err := LoadExistingEntries(dir, "feeds", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(existing) != 1 || existing[0].Source == nil || *existing[0].Source != *source {
+				t.Fatalf("loaded %+v, want the entry with its source restored", existing)
+			}
+			fresh := []entry.Entry{{
+				ID: "a", URL: "https://example.com/a", Title: "A", Date: date,
+				Discussions: []entry.Discussion{reddit, hn},
+			}}
+			second := entry.NewFeed("Test", "", "")
+			second.Entries = MergeEntries(existing, fresh, strategy)
+			if _, err := WriteMonthlyFiles(second, dir, "feeds"); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := LoadExistingEntries(dir, "feeds", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(loaded) != 1 {
+				t.Fatalf("got %d entries, want 1", len(loaded))
+			}
+			got := loaded[0]
+			if len(got.Discussions) != 2 {
+				t.Fatalf("Discussions = %+v, want the Hacker News and Reddit links once each", got.Discussions)
+			}
+			for _, want := range []entry.Discussion{hn, reddit} {
+				if !slices.Contains(got.Discussions, want) {
+					t.Errorf("Discussions = %+v, missing %+v", got.Discussions, want)
+				}
+			}
+		})
+	}
+}
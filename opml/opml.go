@@ -3,33 +3,51 @@ package opml
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/grokify/signal/internal/envvar"
+	"github.com/grokify/signal/internal/fileutil"
 )
 
 // OPML represents an OPML document in JSON format.
 // This allows feed lists to be maintained in JSON while preserving OPML semantics.
 type OPML struct {
-	Version   string    `json:"version,omitempty"`
-	Title     string    `json:"title,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	Title        string    `json:"title,omitempty"`
 	DateCreated  time.Time `json:"dateCreated,omitempty"`
 	DateModified time.Time `json:"dateModified,omitempty"`
-	OwnerName string    `json:"ownerName,omitempty"`
-	OwnerEmail string   `json:"ownerEmail,omitempty"`
-	Outlines  []Outline `json:"outlines"`
+	OwnerName    string    `json:"ownerName,omitempty"`
+	OwnerEmail   string    `json:"ownerEmail,omitempty"`
+	Outlines     []Outline `json:"outlines"`
+
+	// MaxDepth is the deepest outline nesting FlattenFeeds descends into
+	// and CheckDepth allows (0 = DefaultMaxDepth)
+	MaxDepth int `json:"-"`
 }
 
+// DefaultMaxDepth is the default OPML.MaxDepth. Top-level outlines are at
+// depth 1; real feed lists rarely nest more than a few groups.
+const DefaultMaxDepth = 32
+
 // Outline represents an OPML outline element, which can contain feeds or nested outlines.
 type Outline struct {
-	Text        string    `json:"text,omitempty"`
-	Title       string    `json:"title,omitempty"`
-	Type        string    `json:"type,omitempty"`        // "rss", "atom", "link", etc.
-	XMLURL      string    `json:"xmlUrl,omitempty"`      // Feed URL
-	HTMLURL     string    `json:"htmlUrl,omitempty"`     // Website URL
-	Description string    `json:"description,omitempty"`
-	Language    string    `json:"language,omitempty"`
-	Categories  []string  `json:"categories,omitempty"`  // Tags/categories for filtering
-	Outlines    []Outline `json:"outlines,omitempty"`    // Nested outlines (for grouping)
+	Text          string    `json:"text,omitempty"`
+	Title         string    `json:"title,omitempty"`
+	TitleOverride bool      `json:"titleOverride,omitempty"` // Use Title instead of the feed's own title
+	Type          string    `json:"type,omitempty"`          // "rss", "atom", "link", etc.
+	XMLURL        string    `json:"xmlUrl,omitempty"`        // Feed URL
+	HTMLURL       string    `json:"htmlUrl,omitempty"`       // Website URL
+	Description   string    `json:"description,omitempty"`
+	Language      string    `json:"language,omitempty"`
+	DefaultAuthor string    `json:"defaultAuthor,omitempty"` // Author of entries whose feed item names none
+	Categories    []string  `json:"categories,omitempty"`    // Tags/categories for filtering
+	UserAgent     string    `json:"userAgent,omitempty"`     // Per-feed User-Agent override
+	Accept        string    `json:"accept,omitempty"`        // Per-feed Accept header override
+	Disabled      bool      `json:"disabled,omitempty"`      // Skip this feed (and nested outlines) without removing it
+	Outlines      []Outline `json:"outlines,omitempty"`      // Nested outlines (for grouping)
 }
 
 // ReadFile reads an OPML JSON file and returns the parsed OPML structure.
@@ -45,29 +63,170 @@ func ReadFile(filename string) (*OPML, error) {
 	return &opml, nil
 }
 
+// ReadFileExpand is like ReadFile but expands ${VAR} using [envvar.Expand].
+func ReadFileExpand(filename string) (*OPML, error) {
+	o, err := ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	o.Outlines = expandOutlines(o.Outlines)
+	return o, nil
+}
+
+func expandOutlines(outlines []Outline) []Outline {
+	for i := range outlines {
+		outlines[i].XMLURL = envvar.Expand(outlines[i].XMLURL)
+		outlines[i].HTMLURL = envvar.Expand(outlines[i].HTMLURL)
+		outlines[i].UserAgent = envvar.Expand(outlines[i].UserAgent)
+		outlines[i].Outlines = expandOutlines(outlines[i].Outlines)
+	}
+	return outlines
+}
+
 // WriteFile writes an OPML structure to a JSON file.
 func (o *OPML) WriteFile(filename string) error {
+	return o.WriteFileMode(filename, 0644)
+}
+
+// WriteFileMode writes an OPML structure to a JSON file with permissions
+// perm.
+func (o *OPML) WriteFileMode(filename string, perm os.FileMode) error {
 	data, err := json.MarshalIndent(o, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filename, data, 0644)
+	return fileutil.WriteFile(filename, data, perm)
 }
 
-// FlattenFeeds returns all feed outlines from the OPML, flattening any nested structure.
+// FlattenFeeds returns all enabled feed outlines from the OPML, flattening
+// any nested structure. Disabled outlines, and everything nested under
+// them, are skipped, as are outlines nested deeper than MaxDepth; call
+// CheckDepth to detect those.
 func (o *OPML) FlattenFeeds() []Outline {
+	return o.flatten(false)
+}
+
+// FlattenAllFeeds is like FlattenFeeds but includes disabled outlines.
+func (o *OPML) FlattenAllFeeds() []Outline {
+	return o.flatten(true)
+}
+
+func (o *OPML) flatten(includeDisabled bool) []Outline {
 	var feeds []Outline
-	var flatten func(outlines []Outline)
-	flatten = func(outlines []Outline) {
+	maxDepth := o.maxDepth()
+	var flatten func(outlines []Outline, depth int)
+	flatten = func(outlines []Outline, depth int) {
 		for _, outline := range outlines {
+			if outline.Disabled && !includeDisabled {
+				continue
+			}
 			if outline.XMLURL != "" {
 				feeds = append(feeds, outline)
 			}
-			if len(outline.Outlines) > 0 {
-				flatten(outline.Outlines)
+			if len(outline.Outlines) > 0 && depth < maxDepth {
+				flatten(outline.Outlines, depth+1)
 			}
 		}
 	}
-	flatten(o.Outlines)
+	flatten(o.Outlines, 1)
 	return feeds
 }
+
+func (o *OPML) maxDepth() int {
+	if o.MaxDepth > 0 {
+		return o.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+// CheckDepth returns an error naming the first outline nested deeper than
+// MaxDepth, whose feeds FlattenFeeds would skip. This also catches outlines
+// built in code that contain themselves, which would otherwise nest
+// without end.
+func (o *OPML) CheckDepth() error {
+	maxDepth := o.maxDepth()
+	var check func(outlines []Outline, depth int) error
+	check = func(outlines []Outline, depth int) error {
+		for _, outline := range outlines {
+			if depth > maxDepth {
+				name := outline.Title
+				if name == "" {
+					name = outline.Text
+				}
+				if name == "" {
+					name = outline.XMLURL
+				}
+				return fmt.Errorf("outline %q is nested more than %d levels deep", name, maxDepth)
+			}
+			if err := check(outline.Outlines, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(o.Outlines, 1)
+}
+
+// FlattenUniqueFeeds is like FlattenFeeds but merges outlines that share a
+// feed URL. See UniqueFeeds.
+func (o *OPML) FlattenUniqueFeeds() []Outline {
+	return UniqueFeeds(o.FlattenFeeds())
+}
+
+// UniqueFeeds merges outlines that share a feed URL (compared
+// case-insensitively, ignoring a trailing slash). The first occurrence
+// keeps its position; categories from all occurrences are unioned, and the
+// longest title, text, and description are kept.
+func UniqueFeeds(outlines []Outline) []Outline {
+	var feeds []Outline
+	index := make(map[string]int) // normalized URL -> index in feeds
+	for _, f := range outlines {
+		key := strings.ToLower(strings.TrimRight(f.XMLURL, "/"))
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(feeds)
+			f.Categories = unionStrings(nil, f.Categories)
+			feeds = append(feeds, f)
+			continue
+		}
+		merged := &feeds[i]
+		merged.Categories = unionStrings(merged.Categories, f.Categories)
+		merged.Title = longer(merged.Title, f.Title)
+		merged.TitleOverride = merged.TitleOverride || f.TitleOverride
+		merged.Text = longer(merged.Text, f.Text)
+		merged.Description = longer(merged.Description, f.Description)
+		if merged.HTMLURL == "" {
+			merged.HTMLURL = f.HTMLURL
+		}
+		if merged.Accept == "" {
+			merged.Accept = f.Accept
+		}
+		if merged.DefaultAuthor == "" {
+			merged.DefaultAuthor = f.DefaultAuthor
+		}
+	}
+	return feeds
+}
+
+// unionStrings appends values from b not already in a, case-insensitively.
+func unionStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	result := make([]string, 0, len(a)+len(b))
+	for _, s := range append(append([]string{}, a...), b...) {
+		if lower := strings.ToLower(s); s != "" && !seen[lower] {
+			seen[lower] = true
+			result = append(result, s)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func longer(a, b string) string {
+	if len(b) > len(a) {
+		return b
+	}
+	return a
+}
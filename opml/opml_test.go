@@ -0,0 +1,87 @@
+package opml
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenUniqueFeedsMergesSharedURL(t *testing.T) {
+	o := &OPML{Outlines: []Outline{
+		{Text: "Go", XMLURL: "https://go.dev/blog/feed.atom", Categories: []string{"go"}},
+		{Text: "Rust", XMLURL: "https://blog.rust-lang.org/feed.xml", Categories: []string{"rust"}},
+		{Text: "The Go Blog", XMLURL: "https://GO.dev/blog/feed.atom/", Categories: []string{"Go", "programming"}},
+	}}
+
+	feeds := o.FlattenUniqueFeeds()
+	if len(feeds) != 2 {
+		t.Fatalf("got %d feeds, want 2: %+v", len(feeds), feeds)
+	}
+	merged := feeds[0]
+	if merged.XMLURL != "https://go.dev/blog/feed.atom" {
+		t.Errorf("XMLURL = %q, want the first occurrence's", merged.XMLURL)
+	}
+	if want := []string{"go", "programming"}; !reflect.DeepEqual(merged.Categories, want) {
+		t.Errorf("Categories = %q, want %q", merged.Categories, want)
+	}
+	if merged.Text != "The Go Blog" {
+		t.Errorf("Text = %q, want the longer %q", merged.Text, "The Go Blog")
+	}
+	if feeds[1].Text != "Rust" {
+		t.Errorf("feeds[1].Text = %q, want %q", feeds[1].Text, "Rust")
+	}
+}
+
+// nested returns outlines nested depth levels deep, with a feed at each
+// level.
+func nested(depth int) []Outline {
+	var outlines []Outline
+	for d := depth; d >= 1; d-- {
+		outlines = []Outline{{
+			Text:     fmt.Sprintf("level %d", d),
+			XMLURL:   fmt.Sprintf("https://example.com/%d.xml", d),
+			Outlines: outlines,
+		}}
+	}
+	return outlines
+}
+
+func TestDeeplyNestedOutlines(t *testing.T) {
+	tests := []struct {
+		name      string
+		depth     int
+		maxDepth  int
+		wantFeeds int
+		wantErr   bool
+	}{
+		{"within the default", DefaultMaxDepth, 0, DefaultMaxDepth, false},
+		{"beyond the default", DefaultMaxDepth + 8, 0, DefaultMaxDepth, true},
+		{"within a raised MaxDepth", DefaultMaxDepth + 8, 64, DefaultMaxDepth + 8, false},
+		{"beyond a lowered MaxDepth", 5, 3, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &OPML{Outlines: nested(tt.depth), MaxDepth: tt.maxDepth}
+			if got := len(o.FlattenFeeds()); got != tt.wantFeeds {
+				t.Errorf("FlattenFeeds returned %d feeds, want %d", got, tt.wantFeeds)
+			}
+			if err := o.CheckDepth(); (err != nil) != tt.wantErr {
+				t.Errorf("CheckDepth() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutlineCycle(t *testing.T) {
+	// An outline whose children share its backing array contains itself
+	outlines := []Outline{{Text: "loop", XMLURL: "https://example.com/loop.xml"}}
+	outlines[0].Outlines = outlines
+	o := &OPML{Outlines: outlines}
+
+	if err := o.CheckDepth(); err == nil {
+		t.Error("CheckDepth() = nil, want an error for the cycle")
+	}
+	if got := len(o.FlattenFeeds()); got != DefaultMaxDepth {
+		t.Errorf("FlattenFeeds returned %d feeds, want it to stop at %d", got, DefaultMaxDepth)
+	}
+}
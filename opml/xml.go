@@ -0,0 +1,195 @@
+package opml
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/grokify/signal/internal/fileutil"
+)
+
+// xmlOPML is the standard XML representation of an OPML 2.0 document.
+type xmlOPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    xmlHead  `xml:"head"`
+	Body    xmlBody  `xml:"body"`
+}
+
+type xmlHead struct {
+	Title        string `xml:"title,omitempty"`
+	DateCreated  string `xml:"dateCreated,omitempty"`
+	DateModified string `xml:"dateModified,omitempty"`
+	OwnerName    string `xml:"ownerName,omitempty"`
+	OwnerEmail   string `xml:"ownerEmail,omitempty"`
+}
+
+type xmlBody struct {
+	Outlines []xmlOutline `xml:"outline"`
+}
+
+type xmlOutline struct {
+	Text          string       `xml:"text,attr,omitempty"`
+	Title         string       `xml:"title,attr,omitempty"`
+	TitleOverride bool         `xml:"titleOverride,attr,omitempty"`
+	Type          string       `xml:"type,attr,omitempty"`
+	XMLURL        string       `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL       string       `xml:"htmlUrl,attr,omitempty"`
+	Description   string       `xml:"description,attr,omitempty"`
+	Language      string       `xml:"language,attr,omitempty"`
+	DefaultAuthor string       `xml:"defaultAuthor,attr,omitempty"`
+	Category      string       `xml:"category,attr,omitempty"` // Comma-separated per OPML 2.0
+	UserAgent     string       `xml:"userAgent,attr,omitempty"`
+	Accept        string       `xml:"accept,attr,omitempty"`
+	Disabled      bool         `xml:"disabled,attr,omitempty"`
+	Outlines      []xmlOutline `xml:"outline"`
+}
+
+// xmlDateLayouts are the date formats accepted in OPML head elements.
+// OPML specifies RFC 822 dates, but RFC 3339 shows up in the wild.
+var xmlDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339,
+}
+
+// ParseXML parses a standard XML OPML document.
+func ParseXML(data []byte) (*OPML, error) {
+	var x xmlOPML
+	if err := xml.Unmarshal(data, &x); err != nil {
+		return nil, err
+	}
+	return &OPML{
+		Version:      x.Version,
+		Title:        x.Head.Title,
+		DateCreated:  parseXMLDate(x.Head.DateCreated),
+		DateModified: parseXMLDate(x.Head.DateModified),
+		OwnerName:    x.Head.OwnerName,
+		OwnerEmail:   x.Head.OwnerEmail,
+		Outlines:     fromXMLOutlines(x.Body.Outlines),
+	}, nil
+}
+
+// ReadXMLFile reads a standard XML OPML file.
+func ReadXMLFile(filename string) (*OPML, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseXML(data)
+}
+
+// ToXML returns the OPML as a standard XML OPML document, including the XML header.
+func (o *OPML) ToXML() ([]byte, error) {
+	version := o.Version
+	if version == "" {
+		version = "2.0"
+	}
+	x := xmlOPML{
+		Version: version,
+		Head: xmlHead{
+			Title:        o.Title,
+			DateCreated:  formatXMLDate(o.DateCreated),
+			DateModified: formatXMLDate(o.DateModified),
+			OwnerName:    o.OwnerName,
+			OwnerEmail:   o.OwnerEmail,
+		},
+		Body: xmlBody{Outlines: toXMLOutlines(o.Outlines)},
+	}
+	data, err := xml.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// WriteXMLFile writes the OPML to a standard XML OPML file.
+func (o *OPML) WriteXMLFile(filename string) error {
+	return o.WriteXMLFileMode(filename, 0644)
+}
+
+// WriteXMLFileMode writes the OPML to a standard XML OPML file with
+// permissions perm.
+func (o *OPML) WriteXMLFileMode(filename string, perm os.FileMode) error {
+	data, err := o.ToXML()
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(filename, data, perm)
+}
+
+func fromXMLOutlines(xs []xmlOutline) []Outline {
+	var outlines []Outline
+	for _, x := range xs {
+		outlines = append(outlines, Outline{
+			Text:          x.Text,
+			Title:         x.Title,
+			TitleOverride: x.TitleOverride,
+			Type:          x.Type,
+			XMLURL:        x.XMLURL,
+			HTMLURL:       x.HTMLURL,
+			Description:   x.Description,
+			Language:      x.Language,
+			DefaultAuthor: x.DefaultAuthor,
+			Categories:    splitCategories(x.Category),
+			UserAgent:     x.UserAgent,
+			Accept:        x.Accept,
+			Disabled:      x.Disabled,
+			Outlines:      fromXMLOutlines(x.Outlines),
+		})
+	}
+	return outlines
+}
+
+func toXMLOutlines(outlines []Outline) []xmlOutline {
+	var xs []xmlOutline
+	for _, o := range outlines {
+		xs = append(xs, xmlOutline{
+			Text:          o.Text,
+			Title:         o.Title,
+			TitleOverride: o.TitleOverride,
+			Type:          o.Type,
+			XMLURL:        o.XMLURL,
+			HTMLURL:       o.HTMLURL,
+			Description:   o.Description,
+			Language:      o.Language,
+			DefaultAuthor: o.DefaultAuthor,
+			Category:      strings.Join(o.Categories, ","),
+			UserAgent:     o.UserAgent,
+			Accept:        o.Accept,
+			Disabled:      o.Disabled,
+			Outlines:      toXMLOutlines(o.Outlines),
+		})
+	}
+	return xs
+}
+
+func splitCategories(s string) []string {
+	var categories []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+func parseXMLDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range xmlDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func formatXMLDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
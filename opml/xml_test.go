@@ -0,0 +1,93 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fullOPML sets every field that both formats carry.
+func fullOPML() *OPML {
+	return &OPML{
+		Version:      "2.0",
+		Title:        "Planet Test",
+		DateCreated:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		DateModified: time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC),
+		OwnerName:    "Jo Curator",
+		OwnerEmail:   "jo@example.com",
+		Outlines: []Outline{{
+			Text:  "Engineering",
+			Title: "Engineering",
+			Outlines: []Outline{{
+				Text:          "Go Blog",
+				Title:         "The Go Blog",
+				TitleOverride: true,
+				Type:          "rss",
+				XMLURL:        "https://go.dev/blog/feed.atom?a=1&b=2",
+				HTMLURL:       "https://go.dev/blog/",
+				Description:   `News & "notes" <from> the Go team`,
+				Language:      "en",
+				DefaultAuthor: "Go Team",
+				Categories:    []string{"go", "programming"},
+				UserAgent:     "Custom/1.0",
+				Accept:        "application/atom+xml",
+				Disabled:      true,
+			}},
+		}},
+	}
+}
+
+func TestRoundTripJSONToXMLToJSON(t *testing.T) {
+	dir := t.TempDir()
+	want := fullOPML()
+
+	if err := want.WriteFile(filepath.Join(dir, "feeds.json")); err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := ReadFile(filepath.Join(dir, "feeds.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fromJSON.WriteXMLFile(filepath.Join(dir, "feeds.opml")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadXMLFile(filepath.Join(dir, "feeds.opml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Compared as JSON, since time.Parse may put XML dates in time.Local
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Errorf("round trip changed the OPML:\ngot  %s\nwant %s", gotJSON, wantJSON)
+	}
+}
+
+func TestRoundTripXMLToJSONToXML(t *testing.T) {
+	want, err := fullOPML().ToXML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseXML(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := parsed.WriteFile(filepath.Join(dir, "feeds.json")); err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := ReadFile(filepath.Join(dir, "feeds.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := fromJSON.ToXML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("round trip changed the XML:\ngot  %s\nwant %s", got, want)
+	}
+}
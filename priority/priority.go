@@ -3,22 +3,30 @@ package priority
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/internal/envvar"
+	"github.com/grokify/signal/internal/fileutil"
 )
 
 // Link represents a hand-curated priority link.
 type Link struct {
 	Title       string    `json:"title"`
 	URL         string    `json:"url"`
+	ExternalURL string    `json:"external_url,omitempty"` // Linked article, when URL is commentary on it
 	Author      string    `json:"author,omitempty"`
 	Date        time.Time `json:"date,omitempty"`
 	Tags        []string  `json:"tags,omitempty"`
 	Summary     string    `json:"summary,omitempty"`
 	ContentHTML string    `json:"content_html,omitempty"` // Full article content
 	Rank        int       `json:"rank,omitempty"`         // Lower = higher priority
+	Period      string    `json:"period,omitempty"`       // Curation period (defaults to the file's)
 	FeedTitle   string    `json:"feedTitle,omitempty"`
 	FeedURL     string    `json:"feedUrl,omitempty"`
 
@@ -31,21 +39,105 @@ type Link struct {
 
 	// Discussion links (HackerNews, Reddit, Lobsters, etc.)
 	Discussions []Discussion `json:"discussions,omitempty"`
+
+	// dateExpr is a relative date read from JSON, which Links.ResolveDates
+	// turns into Date
+	dateExpr string
+}
+
+// UnmarshalJSON decodes a link, accepting its date in RFC3339, as a plain
+// date ("2026-02-16"), or relative ("today", "yesterday", "2 days ago").
+// A relative date leaves Date unset until Links.ResolveDates.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	type plain Link
+	aux := struct {
+		*plain
+		Date string `json:"date,omitempty"`
+	}{plain: (*plain)(l)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	l.Date, l.dateExpr = time.Time{}, ""
+	if aux.Date == "" {
+		return nil
+	}
+	date, relative, err := parseDate(aux.Date)
+	if err != nil {
+		name := l.Title
+		if name == "" {
+			name = l.URL
+		}
+		return fmt.Errorf("priority link %q: %w", name, err)
+	}
+	if relative {
+		l.dateExpr = aux.Date
+	} else {
+		l.Date = date
+	}
+	return nil
+}
+
+var relativeDate = regexp.MustCompile(`^(\d+)\s+(minute|hour|day|week)s?\s+ago$`)
+
+// parseDate parses an absolute priority link date, or reports that s is a
+// relative date for resolveDate.
+func parseDate(s string) (date time.Time, relative bool, err error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, false, nil
+	}
+	if _, ok := resolveDate(s, time.Time{}); ok {
+		return time.Time{}, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf(`invalid date %q: want RFC3339, YYYY-MM-DD, "today", "yesterday", or "N days ago"`, s)
+}
+
+// resolveDate resolves a relative date against now, reporting false if s
+// isn't one. Relative days resolve to midnight UTC so every run on the
+// same day reads the same date.
+func resolveDate(s string, now time.Time) (time.Time, bool) {
+	now = now.UTC().Truncate(time.Second)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	expr := strings.ToLower(strings.TrimSpace(s))
+	switch expr {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	if m := relativeDate.FindStringSubmatch(expr); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err == nil {
+			switch m[2] {
+			case "minute":
+				return now.Add(-time.Duration(n) * time.Minute), true
+			case "hour":
+				return now.Add(-time.Duration(n) * time.Hour), true
+			case "day":
+				return today.AddDate(0, 0, -n), true
+			case "week":
+				return today.AddDate(0, 0, -7*n), true
+			}
+		}
+	}
+	return time.Time{}, false
 }
 
 // Source represents metadata about the content source platform.
 type Source struct {
-	Platform string `json:"platform"`       // "linkedin", "twitter", "mastodon", etc.
+	Platform string `json:"platform"`         // "linkedin", "twitter", "mastodon", etc.
 	Author   string `json:"author,omitempty"` // Platform-specific author name/handle
 	PostID   string `json:"postId,omitempty"` // Platform-specific post ID
 }
 
 // Discussion represents a link to a discussion forum.
 type Discussion struct {
-	Platform string `json:"platform"`          // "hackernews", "reddit", "lobsters", etc.
-	URL      string `json:"url"`               // Full URL to the discussion
-	ID       string `json:"id,omitempty"`      // Platform-specific ID (e.g., HN item ID)
-	Score    int    `json:"score,omitempty"`   // Upvotes/points at time of capture
+	Platform string `json:"platform"`           // "hackernews", "reddit", "lobsters", etc.
+	URL      string `json:"url"`                // Full URL to the discussion
+	ID       string `json:"id,omitempty"`       // Platform-specific ID (e.g., HN item ID)
+	Score    int    `json:"score,omitempty"`    // Upvotes/points at time of capture
 	Comments int    `json:"comments,omitempty"` // Comment count at time of capture
 }
 
@@ -58,7 +150,70 @@ type Links struct {
 	Links       []Link    `json:"links"`
 }
 
-// ReadFile reads priority links from a JSON file.
+// ResolveDates dates the links read with relative dates ("yesterday")
+// relative to now, the run's reference time. Passing a fixed time, such as
+// one from SOURCE_DATE_EPOCH, keeps the dates, and so the entry IDs
+// derived from them, reproducible. It can be called again with another
+// time.
+func (l *Links) ResolveDates(now time.Time) {
+	for i := range l.Links {
+		if expr := l.Links[i].dateExpr; expr != "" {
+			l.Links[i].Date, _ = resolveDate(expr, now)
+		}
+	}
+}
+
+// Merge combines priority files, e.g., one per month, into one list. Each
+// link keeps its file's period and, when undated, its file's updated time.
+// Links sharing a URL (compared as deduplication does) are kept once, at
+// the first one's position, with the best rank (unranked is worst). The
+// result's period is set only when every file shares one.
+func Merge(lists ...*Links) *Links {
+	merged := &Links{Links: []Link{}}
+	byURL := make(map[string]int) // dedup key -> index in merged.Links
+	for i, l := range lists {
+		if merged.Title == "" {
+			merged.Title = l.Title
+		}
+		if merged.Description == "" {
+			merged.Description = l.Description
+		}
+		if i == 0 {
+			merged.Period = l.Period
+		} else if l.Period != merged.Period {
+			merged.Period = ""
+		}
+		if l.Updated.After(merged.Updated) {
+			merged.Updated = l.Updated
+		}
+		for _, link := range l.Links {
+			if link.Period == "" {
+				link.Period = l.Period
+			}
+			if link.Date.IsZero() && link.dateExpr == "" {
+				link.Date = l.Updated
+			}
+			key := entry.DedupKey(entry.Entry{URL: link.URL}, entry.DedupGlobal)
+			j, ok := byURL[key]
+			if !ok {
+				byURL[key] = len(merged.Links)
+				merged.Links = append(merged.Links, link)
+			} else if betterRank(link.Rank, merged.Links[j].Rank) {
+				merged.Links[j] = link
+			}
+		}
+	}
+	return merged
+}
+
+// betterRank reports whether rank a orders before rank b. Unranked (0)
+// orders after every rank.
+func betterRank(a, b int) bool {
+	return a > 0 && (b == 0 || a < b)
+}
+
+// ReadFile reads priority links from a JSON file. Links with relative
+// dates stay undated until ResolveDates.
 func ReadFile(filename string) (*Links, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -71,13 +226,88 @@ func ReadFile(filename string) (*Links, error) {
 	return &links, nil
 }
 
+// ReadFileExpand is like ReadFile but expands ${VAR} using [envvar.Expand].
+func ReadFileExpand(filename string) (*Links, error) {
+	links, err := ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links.Links {
+		links.Links[i].URL = envvar.Expand(links.Links[i].URL)
+		links.Links[i].ExternalURL = envvar.Expand(links.Links[i].ExternalURL)
+		links.Links[i].FeedURL = envvar.Expand(links.Links[i].FeedURL)
+		links.Links[i].Image = envvar.Expand(links.Links[i].Image)
+	}
+	return links, nil
+}
+
 // WriteFile writes priority links to a JSON file.
 func (l *Links) WriteFile(filename string) error {
+	return l.WriteFileMode(filename, 0644)
+}
+
+// WriteFileMode writes priority links to a JSON file with permissions perm.
+func (l *Links) WriteFileMode(filename string, perm os.FileMode) error {
 	data, err := json.MarshalIndent(l, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filename, data, 0644)
+	return fileutil.WriteFile(filename, data, perm)
+}
+
+// Validate reports curation mistakes: links with an empty URL or title,
+// duplicate URLs (compared as deduplication does), and ranks that are
+// negative, repeated, or lower than an earlier link's. Each error names the
+// link by index and title. Malformed dates already fail in ReadFile.
+func (l *Links) Validate() []error {
+	var errs []error
+	urls := make(map[string]int)  // dedup key -> first link index
+	ranks := make(map[int]int)    // rank -> first link index
+	lastRank, lastRanked := 0, -1 // highest rank so far and its link index
+	for i, link := range l.Links {
+		name := linkName(i, link)
+		if strings.TrimSpace(link.URL) == "" {
+			errs = append(errs, fmt.Errorf("%s: missing url", name))
+		} else {
+			key := entry.DedupKey(entry.Entry{URL: link.URL}, entry.DedupGlobal)
+			if first, ok := urls[key]; ok {
+				errs = append(errs, fmt.Errorf("%s: duplicate url %s (also %s)", name, link.URL, linkName(first, l.Links[first])))
+			} else {
+				urls[key] = i
+			}
+		}
+		if strings.TrimSpace(link.Title) == "" {
+			errs = append(errs, fmt.Errorf("%s: missing title", name))
+		}
+
+		switch {
+		case link.Rank < 0:
+			errs = append(errs, fmt.Errorf("%s: negative rank %d", name, link.Rank))
+			continue
+		case link.Rank == 0:
+			continue
+		}
+		if first, ok := ranks[link.Rank]; ok {
+			errs = append(errs, fmt.Errorf("%s: duplicate rank %d (also %s)", name, link.Rank, linkName(first, l.Links[first])))
+		} else {
+			ranks[link.Rank] = i
+		}
+		if link.Rank < lastRank {
+			errs = append(errs, fmt.Errorf("%s: rank %d is out of order after rank %d (%s)", name, link.Rank, lastRank, linkName(lastRanked, l.Links[lastRanked])))
+		} else {
+			lastRank, lastRanked = link.Rank, i
+		}
+	}
+	return errs
+}
+
+// linkName identifies a link in Validate errors by its index in the file
+// and its title, if any.
+func linkName(i int, link Link) string {
+	if link.Title == "" {
+		return fmt.Sprintf("link %d", i)
+	}
+	return fmt.Sprintf("link %d %q", i, link.Title)
 }
 
 // ToEntries converts priority links to feed entries.
@@ -88,6 +318,10 @@ func (l *Links) ToEntries() []entry.Entry {
 		if date.IsZero() {
 			date = l.Updated
 		}
+		period := link.Period
+		if period == "" {
+			period = l.Period
+		}
 
 		// Convert discussions
 		var discussions []entry.Discussion
@@ -112,25 +346,100 @@ func (l *Links) ToEntries() []entry.Entry {
 		}
 
 		entries[i] = entry.Entry{
-			ID:     entry.GenerateID(link.URL, date),
-			Title:  link.Title,
-			URL:    link.URL,
-			Author: link.Author,
-			Date:   date,
+			ID:          entry.GenerateID(link.URL, date),
+			Title:       link.Title,
+			URL:         link.URL,
+			ExternalURL: link.ExternalURL,
+			Author:      link.Author,
+			Date:        date,
 			Feed: entry.FeedMeta{
 				Title: link.FeedTitle,
 				URL:   link.FeedURL,
 			},
-			Tags:         link.Tags,
-			Summary:      link.Summary,
-			Content:      link.ContentHTML,
-			Image:        link.Image,
-			ImageAlt:     link.ImageAlt,
-			Source:       source,
-			IsPriority:   true,
-			PriorityRank: link.Rank,
-			Discussions:  discussions,
+			Tags:           link.Tags,
+			Summary:        link.Summary,
+			Content:        link.ContentHTML,
+			Image:          link.Image,
+			ImageAlt:       link.ImageAlt,
+			Source:         source,
+			IsPriority:     true,
+			PriorityRank:   link.Rank,
+			PriorityPeriod: period,
+			Discussions:    discussions,
 		}
 	}
 	return entries
 }
+
+// ApplyOverrides merges the links into f. A link whose URL matches entries
+// already in f (compared as deduplication does) enriches them rather than
+// adding a near-duplicate: they are marked priority with the link's rank,
+// and the link's non-empty curated fields (title, author, summary,
+// content, image, external URL, source) replace theirs, its tags are added,
+// and its discussions are merged. Links without a match are added as new
+// entries, as with ToEntries. It returns the number of links that matched.
+func (l *Links) ApplyOverrides(f *entry.Feed) int {
+	byURL := make(map[string][]int)
+	for i, e := range f.Entries {
+		key := entry.DedupKey(e, entry.DedupGlobal)
+		byURL[key] = append(byURL[key], i)
+	}
+
+	matched := 0
+	for _, p := range l.ToEntries() {
+		indexes := byURL[entry.DedupKey(p, entry.DedupGlobal)]
+		if len(indexes) == 0 {
+			f.AddEntry(p)
+			continue
+		}
+		matched++
+		for _, i := range indexes {
+			overlay(&f.Entries[i], p)
+		}
+	}
+	return matched
+}
+
+// overlay applies a priority entry's curation to a fetched entry.
+func overlay(e *entry.Entry, p entry.Entry) {
+	e.IsPriority = true
+	e.PriorityRank = p.PriorityRank
+	e.PriorityPeriod = p.PriorityPeriod
+	if p.Title != "" {
+		e.Title = p.Title
+	}
+	if p.Author != "" {
+		e.Author = p.Author
+	}
+	if p.Summary != "" {
+		e.Summary = p.Summary
+	}
+	if p.Content != "" {
+		e.Content = p.Content
+	}
+	if p.ExternalURL != "" {
+		e.ExternalURL = p.ExternalURL
+	}
+	if p.Image != "" {
+		e.Image = p.Image
+		e.ImageAlt = p.ImageAlt
+	}
+	if p.Source != nil {
+		e.Source = p.Source
+	}
+	for _, tag := range p.Tags {
+		if !containsFold(e.Tags, tag) {
+			e.Tags = append(e.Tags, tag)
+		}
+	}
+	e.Discussions = entry.MergeDiscussions(e.Discussions, p.Discussions)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
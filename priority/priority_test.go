@@ -0,0 +1,54 @@
+package priority
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRelativeDatesResolveAgainstReferenceTime(t *testing.T) {
+	data := []byte(`{"links": [
+		{"title": "Yesterday", "url": "https://example.com/a", "date": "yesterday"},
+		{"title": "Weeks", "url": "https://example.com/b", "date": "2 weeks ago"},
+		{"title": "Fixed", "url": "https://example.com/c", "date": "2026-02-16"}
+	]}`)
+	var links Links
+	if err := json.Unmarshal(data, &links); err != nil {
+		t.Fatal(err)
+	}
+	if !links.Links[0].Date.IsZero() {
+		t.Fatalf("relative date resolved on read: %v", links.Links[0].Date)
+	}
+
+	ref := time.Date(2026, 3, 10, 15, 4, 5, 0, time.UTC)
+	links.ResolveDates(ref)
+	want := []time.Time{
+		time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC),
+	}
+	for i, w := range want {
+		if got := links.Links[i].Date; !got.Equal(w) {
+			t.Errorf("%s: date = %v, want %v", links.Links[i].Title, got, w)
+		}
+	}
+
+	// The same reference time gives the same entry IDs on every run
+	first := links.ToEntries()
+	links.ResolveDates(ref.Add(time.Hour))
+	second := links.ToEntries()
+	for i := range first {
+		if first[i].ID != second[i].ID {
+			t.Errorf("%s: ID changed from %s to %s", first[i].Title, first[i].ID, second[i].ID)
+		}
+	}
+}
+
+func TestInvalidDateNamesLink(t *testing.T) {
+	var link Link
+	err := json.Unmarshal([]byte(`{"title": "Broken", "date": "last tuesday"}`), &link)
+	if err == nil || !strings.Contains(err.Error(), `"Broken"`) {
+		t.Errorf("err = %v, want an error naming the link", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Package rss generates RSS 2.0 feed output from aggregated entries.
+package rss
+
+import (
+	"encoding/xml"
+	"os"
+	"time"
+
+	"github.com/grokify/signal/entry"
+	"github.com/grokify/signal/internal/fileutil"
+)
+
+// Feed represents an RSS 2.0 document.
+type Feed struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	AtomNS  string   `xml:"xmlns:atom,attr"`
+	DCNS    string   `xml:"xmlns:dc,attr"`
+	Channel Channel  `xml:"channel"`
+}
+
+// Channel represents an RSS channel element.
+type Channel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      *AtomLink `xml:"atom:link,omitempty"`
+	Items         []Item    `xml:"item"`
+}
+
+// AtomLink is the atom:link element pointing at the feed itself.
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// Item represents an RSS item element.
+type Item struct {
+	Title       string   `xml:"title,omitempty"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        GUID     `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Description string   `xml:"description,omitempty"`
+	Category    []string `xml:"category,omitempty"`
+}
+
+// GUID represents an RSS guid element.
+type GUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// FromFeed converts an entry.Feed to an RSS Feed. The item description is
+// the entry summary, falling back to its content.
+func FromFeed(f *entry.Feed, feedURL string) *Feed {
+	rssFeed := &Feed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: Channel{
+			Title:         f.Title,
+			Link:          f.HomeURL,
+			Description:   f.Description,
+			LastBuildDate: f.Generated.Format(time.RFC1123Z),
+		},
+	}
+	if rssFeed.Channel.Link == "" {
+		rssFeed.Channel.Link = feedURL
+	}
+	if rssFeed.Channel.Description == "" {
+		rssFeed.Channel.Description = f.Title
+	}
+	if feedURL != "" {
+		rssFeed.Channel.AtomLink = &AtomLink{Href: feedURL, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	for _, e := range f.Entries {
+		item := Item{
+			Title:       e.Title,
+			Link:        e.URL,
+			GUID:        GUID{Value: "urn:signal:" + e.ID},
+			Creator:     e.Author,
+			Description: e.Summary,
+			Category:    e.Tags,
+		}
+		if !e.Date.IsZero() {
+			item.PubDate = e.Date.Format(time.RFC1123Z)
+		}
+		if item.Description == "" {
+			item.Description = e.Content
+		}
+		rssFeed.Channel.Items = append(rssFeed.Channel.Items, item)
+	}
+
+	return rssFeed
+}
+
+// WriteFile writes the RSS feed to a file.
+func (f *Feed) WriteFile(filename string) error {
+	return f.WriteFileMode(filename, 0644)
+}
+
+// WriteFileMode writes the RSS feed to a file with permissions perm.
+func (f *Feed) WriteFileMode(filename string, perm os.FileMode) error {
+	data, err := f.ToXML()
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(filename, data, perm)
+}
+
+// ToXML returns the RSS feed as XML bytes, including the XML header.
+func (f *Feed) ToXML() ([]byte, error) {
+	data, err := xml.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}